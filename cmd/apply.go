@@ -0,0 +1,463 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cloudwego/hertz/cmd/hz/util/logs"
+	"github.com/siliconflow/bizyair-cli/config"
+	"github.com/siliconflow/bizyair-cli/lib"
+	"github.com/siliconflow/bizyair-cli/lib/actions"
+	"github.com/siliconflow/bizyair-cli/meta"
+	"github.com/urfave/cli/v2"
+)
+
+// planOutput plan 的结构化输出
+type planOutput struct {
+	Success bool              `json:"success"`
+	Changed bool              `json:"changed"`
+	Models  []modelPlanOutput `json:"models"`
+}
+
+type modelPlanOutput struct {
+	ModelName string              `json:"model_name"`
+	ModelType string              `json:"model_type"`
+	ModelId   int64               `json:"model_id,omitempty"`
+	Action    string              `json:"action"` // create、update、noop
+	Versions  []versionPlanOutput `json:"versions,omitempty"`
+	Warning   string              `json:"warning,omitempty"`
+	Error     *errorOutput        `json:"error,omitempty"`
+}
+
+type versionPlanOutput struct {
+	Version string   `json:"version"`
+	Action  string   `json:"action"`
+	Changes []string `json:"changes,omitempty"`
+}
+
+// applyOutput apply 的结构化输出
+type applyOutput struct {
+	Success bool               `json:"success"`
+	Plan    planOutput         `json:"plan"`
+	Models  []applyModelOutput `json:"models"`
+}
+
+type applyModelOutput struct {
+	ModelName string         `json:"model_name"`
+	ModelType string         `json:"model_type"`
+	Success   bool           `json:"success"`
+	Uploaded  []string       `json:"uploaded,omitempty"`
+	Edited    []string       `json:"edited,omitempty"`
+	Removed   []string       `json:"removed,omitempty"`
+	ModelId   int64          `json:"model_id,omitempty"` // 删除版本后重新提交得到的模型 ID
+	Warning   string         `json:"warning,omitempty"`
+	Errors    []*errorOutput `json:"errors,omitempty"`
+
+	published []*lib.ModelVersion // 本次上传的版本，用于更新锁文件
+}
+
+// Plan 比较 YAML 配置与远端模型，输出需要执行的变更，不做任何修改
+func Plan(c *cli.Context) error {
+	args, err := globalArgs.Parse(c, meta.CmdPlan)
+	if err != nil {
+		return exitWithError(err, meta.LoadError)
+	}
+	setLogVerbose(args.Verbose)
+	logs.Debugf("args: %#v\n", args)
+
+	_, plans, err := buildPlans(c, args)
+	if err != nil {
+		return exitWithError(err, meta.LoadError)
+	}
+
+	output := newPlanOutput(plans, args.Prune)
+	if structuredOutput() {
+		if err := writeOutput(output); err != nil {
+			return cli.Exit(err, meta.LoadError)
+		}
+	} else {
+		printPlans(os.Stdout, plans, args.Prune)
+	}
+	if !output.Success {
+		return cli.Exit("", meta.ServerError)
+	}
+	return nil
+}
+
+// Apply 按 YAML 配置同步远端模型：只上传新增或文件变化的版本，只修改变化的信息
+func Apply(c *cli.Context) error {
+	args, err := globalArgs.Parse(c, meta.CmdApply)
+	if err != nil {
+		return exitWithError(err, meta.LoadError)
+	}
+	setLogVerbose(args.Verbose)
+	logs.Debugf("args: %#v\n", args)
+
+	cfg, plans, err := buildPlans(c, args)
+	if err != nil {
+		return exitWithError(err, meta.LoadError)
+	}
+	output := applyOutput{Success: true, Plan: newPlanOutput(plans, args.Prune)}
+
+	out := msgOut()
+	printPlans(out, plans, args.Prune)
+	if !output.Plan.Success {
+		return exitWithError(fmt.Errorf("部分模型比较失败，未执行任何变更"), meta.ServerError)
+	}
+	lock, err := openLockFile(args.FilePath, args.BaseDomain, false)
+	if err != nil {
+		return exitWithError(err, meta.LoadError)
+	}
+	if !output.Plan.Changed {
+		if lock != nil {
+			updateLockFromPlans(c.Context, lock, "", args, plans, nil)
+		}
+		if structuredOutput() {
+			return writeOutput(output)
+		}
+		return nil
+	}
+
+	if !args.Yes {
+		if !interactive() {
+			return exitWithError(lib.NewValidationError("非交互模式下请使用 --yes 确认执行，或使用 plan 预览"), meta.LoadError)
+		}
+		if !confirm("确认执行以上变更？", false) {
+			fmt.Fprintln(out, "已取消")
+			return nil
+		}
+	}
+
+	apiKey, err := resolveApiKey(args)
+	if err != nil {
+		return exitWithError(err, meta.LoadError)
+	}
+	opts, err := resolveUploadOptions(args, cfg.Upload)
+	if err != nil {
+		return exitWithError(err, meta.LoadError)
+	}
+	defer opts.close()
+
+	failed := 0
+	applied := make(map[int]applyModelOutput, len(plans))
+	for i, plan := range plans {
+		if !plan.HasChanges(args.Prune) {
+			continue
+		}
+		fmt.Fprintf(out, "\n正在同步 %s（%s）\n", plan.Name, plan.Type)
+		result := applyModelPlan(c.Context, args, opts, apiKey, plan)
+		applied[i] = result
+		output.Models = append(output.Models, result)
+		if result.Success {
+			fmt.Fprintf(out, "✓ %s（%s）同步完成\n", plan.Name, plan.Type)
+		} else {
+			failed++
+			output.Success = false
+			fmt.Fprintf(os.Stderr, "✗ %s（%s）同步失败\n", plan.Name, plan.Type)
+		}
+		if c.Context.Err() != nil {
+			break
+		}
+	}
+	fmt.Fprintf(out, "\n应用完成：成功 %d 个模型，失败 %d 个\n", len(output.Models)-failed, failed)
+	if lock != nil {
+		updateLockFromPlans(c.Context, lock, apiKey, args, plans, applied)
+	}
+
+	if structuredOutput() {
+		if err := writeOutput(output); err != nil {
+			return cli.Exit(err, meta.LoadError)
+		}
+	}
+	if c.Context.Err() != nil {
+		return exitCanceled("已取消，其余模型未同步")
+	}
+	if failed > 0 {
+		return cli.Exit(fmt.Sprintf("%d 个模型同步失败", failed), meta.ServerError)
+	}
+	return nil
+}
+
+// buildPlans 加载配置并逐个模型与远端比较
+func buildPlans(c *cli.Context, args *config.Argument) (*config.YamlConfig, []actions.ModelPlan, error) {
+	if args.FilePath == "" {
+		return nil, nil, lib.NewValidationError("请通过 -f 指定 YAML 配置文件")
+	}
+	cfg, err := loadYamlConfigFile(args.FilePath, args.Vars, config.ValidateSyncConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+	apiKey, err := resolveApiKey(args)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	out := msgOut()
+	plans := make([]actions.ModelPlan, 0, len(cfg.Models))
+	for _, model := range cfg.Models {
+		desired, err := newDesiredModel(model)
+		if err != nil {
+			plans = append(plans, actions.ModelPlan{Name: model.Name, Type: model.Type, Error: err})
+			continue
+		}
+		fmt.Fprintf(out, "正在比较 %s（%s）\n", model.Name, model.Type)
+		plans = append(plans, actions.PlanModel(actions.PlanInput{
+			ApiKey:      apiKey,
+			BaseDomain:  args.BaseDomain,
+			Model:       desired,
+			NoHashCache: args.NoHashCache,
+			Context:     c.Context,
+			OnStatus: func(message string) {
+				logs.Debugf("%s\n", message)
+			},
+		}))
+		if c.Context.Err() != nil {
+			return nil, nil, c.Context.Err()
+		}
+	}
+	return cfg, plans, nil
+}
+
+// newDesiredModel 将 YAML 中的模型转换为期望状态，未指定的版本号按顺序自动递增
+// 未指定 intro/intro_path 的版本介绍留空，不与远端比较
+func newDesiredModel(model config.YamlModel) (actions.DesiredModel, error) {
+	desired := actions.DesiredModel{Name: model.Name, Type: model.Type}
+	for i, ver := range config.AutoIncrementVersionNames(model.Versions) {
+		var intro string
+		if ver.Intro != "" || ver.IntroPath != "" {
+			var err error
+			if intro, err = ver.GetIntroduction(); err != nil {
+				return desired, fmt.Errorf("读取版本 %d 介绍失败: %w", i+1, err)
+			}
+		}
+		desired.Versions = append(desired.Versions, actions.DesiredVersion{
+			Version:      ver.Name,
+			Path:         ver.ModelPath,
+			BaseModel:    ver.BaseModel,
+			Introduction: intro,
+			CoverUrl:     ver.GetCoverInput(),
+			Public:       ver.Public,
+		})
+	}
+	return desired, nil
+}
+
+// applyModelPlan 执行单个模型的变更：先上传新增与文件变化的版本，再修改信息，最后删除多余版本
+func applyModelPlan(ctx context.Context, args *config.Argument, opts *uploadOptions, apiKey string, plan actions.ModelPlan) applyModelOutput {
+	out := msgOut()
+	result := applyModelOutput{ModelName: plan.Name, ModelType: plan.Type, Success: true}
+	fail := func(err error) {
+		result.Success = false
+		result.Errors = append(result.Errors, newErrorOutput(err))
+		fmt.Fprintf(os.Stderr, "  ✗ %v\n", err)
+	}
+
+	if versions := plan.UploadVersions(); len(versions) > 0 {
+		// 已存在的模型连同远端其余版本一起提交
+		input := actions.UploadInput{
+			ApiKey:      apiKey,
+			BaseDomain:  args.BaseDomain,
+			ModelType:   plan.Type,
+			ModelName:   plan.Name,
+			Versions:    versions,
+			Update:      !plan.Create,
+			NoHashCache: args.NoHashCache,
+			Context:     ctx,
+		}
+		opts.apply(&input)
+		upload := actions.ExecuteUpload(input, newCliUploadCallback(fmt.Sprintf("[%s] ", plan.Name)))
+		if upload.CanceledByUser {
+			fail(context.Canceled)
+			return result
+		}
+		for _, ver := range upload.Versions {
+			result.Uploaded = append(result.Uploaded, ver.Version)
+		}
+		result.published = upload.Versions
+		for _, err := range upload.Errors {
+			fail(err)
+		}
+		if !upload.Success {
+			if upload.PendingCommit {
+				printPendingCommitHint(plan.Name, plan.Type)
+			}
+			return result
+		}
+	}
+
+	var prune []string
+	for i := range plan.Versions {
+		vp := &plan.Versions[i]
+		switch {
+		case vp.Action == actions.VersionEdit:
+			input := vp.EditInput()
+			input.ApiKey = apiKey
+			input.BaseDomain = args.BaseDomain
+			input.ModelId = plan.ModelId
+			input.Context = ctx
+			edit := actions.ExecuteEditVersion(input)
+			if edit.Error != nil {
+				fail(edit.Error)
+				continue
+			}
+			result.Edited = append(result.Edited, vp.Version)
+			fmt.Fprintf(out, "  ~ 已修改版本 %s\n", vp.Version)
+		case vp.Action == actions.VersionRemove && args.Prune:
+			prune = append(prune, vp.Version)
+		}
+	}
+
+	// 多余的版本一次删除（删除模型后重新提交其余版本），需在其他变更成功后进行
+	if len(prune) > 0 && result.Success {
+		result.Warning = removeVersionWarning
+		del := actions.DeleteModelVersions(ctx, apiKey, args.BaseDomain, plan.ModelId, prune)
+		if !del.Success {
+			fail(del.Error)
+			return result
+		}
+		result.Removed = prune
+		result.ModelId = del.ModelId
+		fmt.Fprintf(out, "  - 已删除版本 %s\n", strings.Join(prune, ", "))
+		printNewModelId(out, plan.ModelId, del.ModelId)
+	}
+	return result
+}
+
+// updateLockFromPlans 将同步后的模型写入锁文件
+// 未变更的模型直接使用比较时获取的远端详情；变更成功的模型重新查询；失败或未执行的模型保留原记录
+func updateLockFromPlans(ctx context.Context, lock *config.LockFile, apiKey string, args *config.Argument, plans []actions.ModelPlan, applied map[int]applyModelOutput) {
+	yamlDir := filepath.Dir(args.FilePath)
+	changed := false
+	for i, plan := range plans {
+		detail := plan.Remote
+		result, ok := applied[i]
+		if ok {
+			if !result.Success {
+				continue
+			}
+			var err error
+			if detail, err = fetchModelDetail(ctx, apiKey, args.BaseDomain, plan.Name, plan.Type); err != nil {
+				fmt.Fprintf(os.Stderr, "⚠ 更新锁文件失败，模型 '%s' 未记录: %v\n", plan.Name, err)
+				continue
+			}
+		} else if plan.HasChanges(args.Prune) || detail == nil {
+			continue
+		}
+		paths := make(map[string]string, len(plan.Versions))
+		for _, vp := range plan.Versions {
+			if vp.Desired != nil {
+				paths[vp.Version] = lockRelPath(yamlDir, vp.Desired.Path)
+			}
+		}
+		refreshLockModel(lock, detail, paths, result.published)
+		changed = true
+	}
+	if !changed {
+		return
+	}
+	if err := lock.Save(); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠ %v\n", err)
+		return
+	}
+	fmt.Fprintf(msgOut(), "已更新锁文件: %s\n", lock.Path())
+}
+
+// planMarks 各变更类型在计划中的标记
+var planMarks = map[string]string{
+	actions.VersionUnchanged: "=",
+	actions.VersionAdd:       "+",
+	actions.VersionReupload:  "↻",
+	actions.VersionEdit:      "~",
+	actions.VersionRemove:    "-",
+}
+
+// printPlans 输出变更计划及汇总
+func printPlans(w io.Writer, plans []actions.ModelPlan, prune bool) {
+	var models, adds, reuploads, edits, removes, extra int
+	fmt.Fprintln(w)
+	for i := range plans {
+		plan := &plans[i]
+		switch {
+		case plan.Error != nil:
+			fmt.Fprintf(w, "✗ %s（%s）比较失败: %v\n", plan.Name, plan.Type, plan.Error)
+			continue
+		case plan.Create:
+			models++
+			fmt.Fprintf(w, "+ %s（%s）新建，%d 个版本\n", plan.Name, plan.Type, len(plan.Versions))
+		case plan.HasChanges(prune):
+			fmt.Fprintf(w, "~ %s（%s, id=%d）\n", plan.Name, plan.Type, plan.ModelId)
+		default:
+			fmt.Fprintf(w, "= %s（%s, id=%d）无变化\n", plan.Name, plan.Type, plan.ModelId)
+		}
+
+		for _, vp := range plan.Versions {
+			line := vp.Version
+			switch vp.Action {
+			case actions.VersionUnchanged:
+				continue
+			case actions.VersionAdd:
+				adds++
+				line += "  " + vp.Desired.Path
+			case actions.VersionReupload:
+				reuploads++
+				line += "  重新上传，" + strings.Join(vp.Changes, "；")
+			case actions.VersionEdit:
+				edits++
+				line += "  " + strings.Join(vp.Changes, "；")
+			case actions.VersionRemove:
+				if !prune {
+					extra++
+					fmt.Fprintf(w, "    ? %s  配置中没有该版本（使用 --prune 删除）\n", vp.Version)
+					continue
+				}
+				removes++
+				line += "  删除"
+			}
+			fmt.Fprintf(w, "    %s %s\n", planMarks[vp.Action], line)
+		}
+	}
+
+	fmt.Fprintf(w, "\n计划：新建 %d 个模型，新增 %d 个版本，重新上传 %d 个，修改 %d 个，删除 %d 个\n", models, adds, reuploads, edits, removes)
+	if extra > 0 {
+		fmt.Fprintf(w, "另有 %d 个远端版本不在配置中，使用 --prune 删除\n", extra)
+	}
+	if removes > 0 {
+		fmt.Fprintf(w, "注意：%s\n", removeVersionWarning)
+	}
+}
+
+func newPlanOutput(plans []actions.ModelPlan, prune bool) planOutput {
+	output := planOutput{Success: true, Models: make([]modelPlanOutput, 0, len(plans))}
+	for i := range plans {
+		plan := &plans[i]
+		model := modelPlanOutput{ModelName: plan.Name, ModelType: plan.Type, ModelId: plan.ModelId, Action: "noop"}
+		switch {
+		case plan.Error != nil:
+			output.Success = false
+			model.Action = "error"
+			model.Error = newErrorOutput(plan.Error)
+		case plan.Create:
+			model.Action = "create"
+		case plan.HasChanges(prune):
+			model.Action = "update"
+		}
+		if model.Action == "create" || model.Action == "update" {
+			output.Changed = true
+		}
+		for _, vp := range plan.Versions {
+			if vp.Action == actions.VersionRemove {
+				if !prune {
+					continue
+				}
+				model.Warning = removeVersionWarning
+			}
+			model.Versions = append(model.Versions, versionPlanOutput{Version: vp.Version, Action: vp.Action, Changes: vp.Changes})
+		}
+		output.Models = append(output.Models, model)
+	}
+	return output
+}
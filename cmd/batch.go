@@ -0,0 +1,242 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudwego/hertz/cmd/hz/util/logs"
+	"github.com/siliconflow/bizyair-cli/config"
+	"github.com/siliconflow/bizyair-cli/lib"
+	"github.com/siliconflow/bizyair-cli/lib/actions"
+)
+
+// openBatchState 读取 YAML 批量上传的进度记录
+// 有未完成的记录时：指定 --resume 则继续；交互终端下询问用户；否则提示后重新开始
+// resumed 表示从上次中断处继续
+func openBatchState(args *config.Argument, yamlPath string) (state *lib.BatchState, resumed bool) {
+	configPath, err := filepath.Abs(yamlPath)
+	if err != nil {
+		configPath = yamlPath
+	}
+	fresh := lib.NewBatchState(args.BaseDomain, configPath)
+	state, err = lib.LoadBatchState(args.BaseDomain, configPath)
+	if err != nil {
+		logs.Warnf("%v\n", err)
+		return fresh, false
+	}
+
+	out := msgOut()
+	if state == nil || state.Done() {
+		if args.Resume {
+			fmt.Fprintln(out, "没有未完成的批量上传记录，将从头开始")
+		}
+		return fresh, false
+	}
+	committed := 0
+	for _, m := range state.Models {
+		if m.Status == lib.BatchCommitted {
+			committed++
+		}
+	}
+	fmt.Fprintf(out, "检测到该配置上次批量上传未完成：%d/%d 个模型已提交，更新于 %s\n",
+		committed, len(state.Models), state.UpdatedAt.Format("2006-01-02 15:04:05"))
+	if args.Resume {
+		return state, true
+	}
+	if !interactive() {
+		fmt.Fprintln(out, "可使用 --resume 从中断处继续，本次将重新开始")
+		return fresh, false
+	}
+	if confirm("是否从中断处继续？", false) {
+		return state, true
+	}
+	return fresh, false
+}
+
+// syncBatchModels 按配置重建进度记录中的模型列表
+// 路径与封面未变化的版本保留原进度，其余版本重新开始；返回各模型是否已在远端创建
+func syncBatchModels(state *lib.BatchState, cfg *config.YamlConfig) []bool {
+	exists := make([]bool, len(cfg.Models))
+	err := state.Update(func() {
+		models := make([]*lib.BatchModel, len(cfg.Models))
+		for i, model := range cfg.Models {
+			prev := state.Model(model.Name, model.Type)
+			exists[i] = prev.Committed()
+			bm := &lib.BatchModel{Name: model.Name, Type: model.Type, Status: lib.BatchPending}
+			for _, ver := range config.AutoIncrementVersionNames(model.Versions) {
+				bv := prev.Version(ver.Name)
+				if bv == nil || bv.Path != ver.ModelPath || bv.Cover != ver.GetCoverInput() {
+					bv = &lib.BatchVersion{Version: ver.Name, Path: ver.ModelPath, Cover: ver.GetCoverInput(), Status: lib.BatchPending}
+				}
+				bm.Versions = append(bm.Versions, bv)
+			}
+			models[i] = bm
+		}
+		state.Models = models
+	})
+	if err != nil {
+		logs.Warnf("保存批量上传进度失败: %v\n", err)
+	}
+	return exists
+}
+
+// resumeBatchVersions 过滤上次已提交的版本，并找出文件与封面已上传、可直接提交的版本
+// 本地文件签名与记录不一致的版本重新上传
+func resumeBatchVersions(ctx context.Context, bm *lib.BatchModel, versions []config.YamlVersion, useCache bool) ([]config.YamlVersion, map[string]*lib.ModelVersion, error) {
+	var pending []config.YamlVersion
+	uploaded := make(map[string]*lib.ModelVersion)
+	for _, ver := range versions {
+		bv := bm.Version(ver.Name)
+		if bv == nil || (bv.Status != lib.BatchCommitted && bv.Status != lib.BatchUploaded) {
+			pending = append(pending, ver)
+			continue
+		}
+		ok, _, err := matchPathSignature(ctx, ver.ModelPath, bv.Sign, bv.Files, useCache)
+		if err != nil {
+			return nil, nil, fmt.Errorf("版本 %s: 计算文件签名失败: %w", ver.Name, err)
+		}
+		if ok && bv.Status == lib.BatchCommitted {
+			continue
+		}
+		if ok {
+			uploaded[ver.Name] = &lib.ModelVersion{Version: bv.Version, Sign: bv.Sign, Files: bv.Files, CoverUrls: bv.CoverUrls}
+		}
+		pending = append(pending, ver)
+	}
+	return pending, uploaded, nil
+}
+
+// pendingMatchesBatch 模型的待提交记录是否与进度记录一致：记录中上传的每个版本在进度记录中均为已上传且签名相同
+// 一致时从中断处继续可直接提交，无需询问
+func pendingMatchesBatch(baseDomain string, bm *lib.BatchModel) bool {
+	if bm == nil {
+		return false
+	}
+	pending, err := lib.LoadPendingCommit(baseDomain, bm.Type, bm.Name)
+	if err != nil || pending == nil {
+		return false
+	}
+	for _, mv := range pending.UploadedVersions() {
+		bv := bm.Version(mv.Version)
+		if bv == nil || bv.Status != lib.BatchUploaded || bv.Sign != mv.Sign || len(bv.Files) != len(mv.Files) {
+			return false
+		}
+		for i, f := range bv.Files {
+			if f.Path != mv.Files[i].Path || f.Sign != mv.Files[i].Sign {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// batchUploadHooks 将上次已上传的版本与进度记录接入上传流程
+func batchUploadHooks(state *lib.BatchState, modelName, modelType string, uploaded map[string]*lib.ModelVersion) func(input *actions.UploadInput) {
+	return func(input *actions.UploadInput) {
+		input.Uploaded = uploaded
+		input.OnVersionUploaded = func(_ int, mv *lib.ModelVersion) {
+			err := state.Update(func() {
+				bv := state.Model(modelName, modelType).Version(mv.Version)
+				if bv == nil {
+					return
+				}
+				bv.Status = lib.BatchUploaded
+				bv.Sign = mv.Sign
+				bv.Files = mv.Files
+				bv.CoverUrls = mv.CoverUrls
+				bv.Error = ""
+			})
+			if err != nil {
+				logs.Warnf("保存批量上传进度失败: %v\n", err)
+			}
+		}
+	}
+}
+
+// recordBatchResult 记录模型的上传结果
+// attempted 为本次尝试上传的版本；取消时保留原状态，下次继续
+func recordBatchResult(ctx context.Context, state *lib.BatchState, result modelUploadResult, attempted []config.YamlVersion) {
+	err := state.Update(func() {
+		bm := state.Model(result.ModelName, result.ModelType)
+		if bm == nil {
+			return
+		}
+		committed := make(map[string]bool)
+		if result.Success {
+			for _, mv := range result.Versions {
+				committed[mv.Version] = true
+			}
+		}
+		canceled := ctx.Err() != nil
+		for _, ver := range attempted {
+			bv := bm.Version(ver.Name)
+			switch {
+			case bv == nil:
+			case committed[ver.Name]:
+				bv.Status = lib.BatchCommitted
+				bv.Error = ""
+			case !canceled && bv.Status != lib.BatchUploaded:
+				bv.Status = lib.BatchFailed
+				if result.Error != nil {
+					bv.Error = result.Error.Error()
+				}
+			}
+		}
+		setBatchModelStatus(bm, canceled, result.Error)
+	})
+	if err != nil {
+		logs.Warnf("保存批量上传进度失败: %v\n", err)
+	}
+}
+
+// recordBatchSkipped 已按锁文件发布或上次已提交的模型，记为全部提交
+func recordBatchSkipped(state *lib.BatchState, modelName, modelType string) {
+	err := state.Update(func() {
+		bm := state.Model(modelName, modelType)
+		if bm == nil {
+			return
+		}
+		for _, bv := range bm.Versions {
+			bv.Status = lib.BatchCommitted
+			bv.Error = ""
+		}
+		bm.Status = lib.BatchCommitted
+		bm.Error = ""
+	})
+	if err != nil {
+		logs.Warnf("保存批量上传进度失败: %v\n", err)
+	}
+}
+
+// setBatchModelStatus 所有版本均已提交时模型记为已提交；取消时保持未完成
+func setBatchModelStatus(bm *lib.BatchModel, canceled bool, err error) {
+	bm.Status = lib.BatchCommitted
+	bm.Error = ""
+	for _, bv := range bm.Versions {
+		if bv.Status != lib.BatchCommitted {
+			bm.Status = lib.BatchFailed
+			break
+		}
+	}
+	if bm.Status == lib.BatchCommitted {
+		return
+	}
+	if canceled {
+		bm.Status = lib.BatchPending
+	} else if err != nil {
+		bm.Error = err.Error()
+	}
+}
+
+// finishBatchState 全部完成时删除进度记录，否则提示如何继续
+func finishBatchState(state *lib.BatchState, yamlPath string) {
+	if state.Done() {
+		if err := lib.DeleteBatchState(state.BaseDomain, state.ConfigPath); err != nil {
+			logs.Warnf("%v\n", err)
+		}
+		return
+	}
+	fmt.Fprintf(os.Stderr, "\n批量上传未全部完成，进度已保存。可运行 `bizyair upload -f %s --resume` 从中断处继续\n", yamlPath)
+}
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/cloudwego/hertz/cmd/hz/util/logs"
+	"github.com/siliconflow/bizyair-cli/lib"
+	"github.com/siliconflow/bizyair-cli/meta"
+	"github.com/urfave/cli/v2"
+)
+
+// clearCacheOutput cache clear 的结构化输出
+type clearCacheOutput struct {
+	Success bool `json:"success"`
+	Removed int  `json:"removed"`
+}
+
+func ClearCache(c *cli.Context) error {
+	args, err := globalArgs.Parse(c, meta.CmdClear)
+	if err != nil {
+		return exitWithError(err, meta.LoadError)
+	}
+	setLogVerbose(args.Verbose)
+	logs.Debugf("args: %#v\n", args)
+
+	removed, err := lib.ClearHashCache()
+	if err != nil {
+		return exitWithError(lib.WithStep("清空哈希缓存", err), meta.LoadError)
+	}
+
+	if structuredOutput() {
+		return writeOutput(clearCacheOutput{Success: true, Removed: removed})
+	}
+	fmt.Fprintf(os.Stdout, "已清空哈希缓存（%d 条记录）\n", removed)
+	return nil
+}
@@ -6,6 +6,8 @@ import (
 	"github.com/cloudwego/hertz/cmd/hz/util/logs"
 	tuiPkg "github.com/siliconflow/bizyair-cli/cmd/tui"
 	"github.com/siliconflow/bizyair-cli/config"
+	"github.com/siliconflow/bizyair-cli/lib"
+	"github.com/siliconflow/bizyair-cli/lib/format"
 	"github.com/siliconflow/bizyair-cli/meta"
 	"github.com/urfave/cli/v2"
 )
@@ -18,7 +20,7 @@ func Init() *cli.App {
 	baseDomainFlag := cli.StringFlag{Name: "base_domain", Usage: "Specify the request domain.", Destination: &globalArgs.BaseDomain, Value: meta.DefaultDomain, Required: false}
 	apiKeyFlag := cli.StringFlag{Name: "api_key", Aliases: []string{"k"}, Usage: "Specify the api key.", EnvVars: []string{meta.EnvAPIKey}, Destination: &globalArgs.ApiKey}
 	typeFlag := cli.StringFlag{Name: "type", Aliases: []string{"t"}, Usage: fmt.Sprintf("Specify the mode type. (Only works for %s)", meta.ModelTypesStr), Destination: &globalArgs.Type}
-	pathFlag := cli.StringSliceFlag{Name: "path", Aliases: []string{"p"}, Usage: "Specify the path to upload.", Destination: &cli.StringSlice{}}
+	pathFlag := cli.StringSliceFlag{Name: "path", Aliases: []string{"p"}, Usage: "Specify the path to upload. A directory is uploaded as one version with its relative paths preserved.", Destination: &cli.StringSlice{}}
 	nameFlag := cli.StringFlag{Name: "name", Aliases: []string{"n"}, Usage: "Specify the name of model.", Destination: &globalArgs.Name}
 	overwriteFlag := cli.BoolFlag{Name: "overwrite", Usage: "Overwrite existent model", Destination: &globalArgs.Overwrite, Value: false, Required: false}
 	// hostFlag := cli.StringFlag{Name: "host", Usage: fmt.Sprintf("Specify the request host, default: %s", meta.DefaultHost), Destination: &globalArgs.Host, Value: meta.DefaultHost}
@@ -30,6 +32,49 @@ func Init() *cli.App {
 	coverUrlsFlag := cli.StringSliceFlag{Name: "cover", Usage: "Urls of model covers, use ';' as separator.", Destination: &cli.StringSlice{}}
 	baseModelFlag := cli.StringSliceFlag{Name: "base", Aliases: []string{"b"}, Usage: fmt.Sprintf("Specify the base model of uploaded model. (Only works for %s)", meta.BaseModelStr), Required: false, Destination: &cli.StringSlice{}}
 	fileFlag := cli.StringFlag{Name: "file", Aliases: []string{"f"}, Usage: "从 YAML 配置文件批量上传", Destination: &globalArgs.FilePath}
+	keywordFlag := cli.StringFlag{Name: "keyword", Usage: "按关键字搜索模型", Destination: &globalArgs.Keyword}
+	sortFlag := cli.StringFlag{Name: "sort", Usage: "模型排序方式，如 Recently", Destination: &globalArgs.Sort, Value: "Recently"}
+	currentFlag := cli.IntFlag{Name: "current", Usage: "起始页码", Destination: &globalArgs.Current, Value: 1}
+	pageSizeFlag := cli.IntFlag{Name: "page-size", Usage: "每页查询的模型数量", Destination: &globalArgs.PageSize, Value: 100}
+	outputFlag := cli.StringFlag{Name: "output", Usage: fmt.Sprintf("输出格式（%s|%s），结构化输出时进度与提示写到 stderr", meta.OutputJSON, meta.OutputYAML),
+		Action: func(c *cli.Context, v string) error {
+			// 子命令与全局共用该 flag，仅在显式指定时写入，避免子命令解析时用默认值覆盖全局设置
+			if err := validateOutputFormat(v); err != nil {
+				return cli.Exit(err, meta.LoadError)
+			}
+			globalArgs.Output = v
+			return nil
+		}}
+	webFlag := cli.BoolFlag{Name: "web", Usage: "在浏览器中打开我的模型页面", Destination: &globalArgs.Web}
+	partSizeFlag := cli.StringFlag{Name: "part-size", Usage: fmt.Sprintf("分片大小，如 64MB，超过 %d 个分片时自动放大", meta.MultipartMaxParts), DefaultText: format.FormatBytes(meta.MultipartPartSize), EnvVars: []string{meta.EnvPartSize}, Destination: &globalArgs.PartSize}
+	parallelFlag := cli.IntFlag{Name: "parallel", Usage: "全局并发上传请求数（所有文件的分片共享）", DefaultText: fmt.Sprint(meta.UploadParallel), EnvVars: []string{meta.EnvParallel}, Destination: &globalArgs.Parallel}
+	thresholdFlag := cli.StringFlag{Name: "multipart-threshold", Usage: "文件达到该大小时使用分片上传，如 100MB", DefaultText: format.FormatBytes(meta.MultipartThreshold), EnvVars: []string{meta.EnvMultipartThreshold}, Destination: &globalArgs.Threshold}
+	limitRateFlag := cli.StringFlag{Name: "limit-rate", Usage: "限制上传总带宽，如 20MB/s（所有并发上传共享）", EnvVars: []string{meta.EnvLimitRate},
+		Action: func(c *cli.Context, v string) error {
+			// 全局（TUI）与 upload 子命令共用该 flag，仅在显式指定时写入
+			if _, err := format.ParseRate(v); err != nil {
+				return cli.Exit(fmt.Errorf("limit-rate 参数无效: %w", err), meta.LoadError)
+			}
+			globalArgs.LimitRate = v
+			return nil
+		}}
+	httpTimeoutFlag := cli.DurationFlag{Name: "timeout", Usage: "API 单次请求超时（0 表示不限制）", EnvVars: []string{meta.EnvHTTPTimeout}, Value: meta.HTTPTimeout, Destination: &globalArgs.HTTPTimeout}
+	httpRetriesFlag := cli.IntFlag{Name: "retries", Usage: "API 幂等请求遇到网络错误、5xx、429 时的最大重试次数", EnvVars: []string{meta.EnvHTTPRetries}, Value: meta.HTTPMaxRetries, Destination: &globalArgs.HTTPRetries}
+	appendFlag := cli.BoolFlag{Name: "append", Usage: "向已存在的模型追加版本，只上传新版本，已有版本原样一起提交（未指定 --version 时接着已有版本自动编号）", Destination: &globalArgs.Append}
+	yesFlag := cli.BoolFlag{Name: "yes", Aliases: []string{"y"}, Usage: "跳过确认，直接执行（用于脚本）", Destination: &globalArgs.Yes}
+	dryRunFlag := cli.BoolFlag{Name: "dry-run", Usage: "仅显示将要删除的内容，不执行删除", Destination: &globalArgs.DryRun}
+	globFlag := cli.StringFlag{Name: "glob", Usage: "批量删除名称匹配通配符的模型，如 'test_*'", Destination: &globalArgs.Glob}
+	regexFlag := cli.StringFlag{Name: "regex", Usage: "批量删除名称完整匹配正则表达式的模型，如 'tmp-.*'", Destination: &globalArgs.Regex}
+	idFlag := cli.Int64Flag{Name: "id", Usage: "按模型 ID 指定模型，不按名称匹配", Destination: &globalArgs.ModelId}
+	dirFlag := cli.StringFlag{Name: "dir", Aliases: []string{"o"}, Usage: "下载保存目录", Value: ".", Destination: &globalArgs.Dir}
+	withCoversFlag := cli.BoolFlag{Name: "with-covers", Usage: "同时下载封面，保存在模型文件旁边", Destination: &globalArgs.WithCovers}
+	withIntroFlag := cli.BoolFlag{Name: "with-intro", Usage: "同时将版本介绍写入与模型文件同名的 .md 文件", Destination: &globalArgs.WithIntro}
+	pruneFlag := cli.BoolFlag{Name: "prune", Usage: "删除远端存在但配置中没有的版本", Destination: &globalArgs.Prune}
+	frozenFlag := cli.BoolFlag{Name: "frozen", Usage: "要求 YAML 对应的锁文件（*.lock.json）与本地文件签名一致，不一致时报错，不上传也不更新锁文件", Destination: &globalArgs.Frozen}
+	resumeFlag := cli.BoolFlag{Name: "resume", Usage: "YAML 批量上传（-f）从上次中断处继续：跳过已提交的模型和版本，已上传的文件直接提交", Destination: &globalArgs.Resume}
+	resumeCommitFlag := cli.BoolFlag{Name: "resume-commit", Usage: "跳过上传，使用上次提交失败时保存的记录直接提交模型", Destination: &globalArgs.ResumeCommit}
+	varFlag := cli.StringSliceFlag{Name: "var", Usage: "设置 YAML 中 ${name} 引用的变量，格式 key=value，可多次指定（优先于 vars 与环境变量）", Destination: &cli.StringSlice{}}
+	noHashCacheFlag := cli.BoolFlag{Name: "no-hash-cache", Usage: "不使用本地哈希缓存，总是重新计算文件哈希", Destination: &globalArgs.NoHashCache}
 
 	app := cli.NewApp()
 	app.Name = meta.Name
@@ -44,6 +89,25 @@ func Init() *cli.App {
 		&verboseFlag,
 		&baseDomainFlag,
 		&apiKeyFlag,
+		&limitRateFlag,
+		&httpTimeoutFlag,
+		&httpRetriesFlag,
+		&outputFlag,
+	}
+
+	// 全局参数在所有命令（含 TUI）执行前生效
+	app.Before = func(c *cli.Context) error {
+		if globalArgs.HTTPRetries < 0 || globalArgs.HTTPTimeout < 0 {
+			return cli.Exit("timeout 与 retries 不能为负数", meta.LoadError)
+		}
+		httpConfig := lib.DefaultHTTPConfig()
+		httpConfig.Timeout = globalArgs.HTTPTimeout
+		httpConfig.MaxRetries = globalArgs.HTTPRetries
+		lib.SetDefaultHTTPConfig(httpConfig)
+
+		setLogVerbose(globalArgs.Verbose)
+		logs.Debugf("http policy: %s\n", httpConfig)
+		return nil
 	}
 
 	// 默认无参进入主 TUI
@@ -56,13 +120,14 @@ func Init() *cli.App {
 			Usage: "登录到 BizyAir",
 			Flags: []cli.Flag{
 				&apiKeyFlag,
+				&outputFlag,
 			},
 			Action: Login,
 		},
 		{
 			Name:   meta.CmdLogout,
 			Usage:  "退出登录",
-			Flags:  []cli.Flag{},
+			Flags:  []cli.Flag{&outputFlag},
 			Action: Logout,
 		},
 		{
@@ -70,44 +135,178 @@ func Init() *cli.App {
 			Usage: "上传文件或文件夹到 BizyAir 模型目录",
 			Flags: []cli.Flag{
 				&fileFlag,
+				&varFlag,
 				&typeFlag,
 				&pathFlag,
 				&nameFlag,
 				&overwriteFlag,
+				&appendFlag,
 				&versionFlag,
 				&versionPublicFlag,
 				&introFlag,
 				&introPathFlag,
 				&baseModelFlag,
 				&coverUrlsFlag,
+				&noHashCacheFlag,
+				&resumeCommitFlag,
+				&resumeFlag,
+				&frozenFlag,
+				&partSizeFlag,
+				&parallelFlag,
+				&thresholdFlag,
+				&limitRateFlag,
+				&outputFlag,
 				// &hostFlag,
 				// &portFlag,
 			},
 			Action: Upload,
 		},
+		{
+			Name:  meta.CmdPlan,
+			Usage: "比较 YAML 配置与远端模型，显示 apply 将执行的变更",
+			Flags: []cli.Flag{
+				&fileFlag,
+				&varFlag,
+				&pruneFlag,
+				&noHashCacheFlag,
+				&outputFlag,
+			},
+			Action: Plan,
+		},
+		{
+			Name:  meta.CmdApply,
+			Usage: "按 YAML 配置同步远端模型，只上传新增或文件变化的版本、只修改变化的信息",
+			Flags: []cli.Flag{
+				&fileFlag,
+				&varFlag,
+				&pruneFlag,
+				&yesFlag,
+				&noHashCacheFlag,
+				&partSizeFlag,
+				&parallelFlag,
+				&thresholdFlag,
+				&limitRateFlag,
+				&outputFlag,
+			},
+			Action: Apply,
+		},
 		{
 			Name:  meta.CmdModel,
-			Usage: "{ls, rm} 与模型交互的命令集",
+			Usage: "{ls, detail, edit, pull, export, rm} 与模型交互的命令集",
 			Subcommands: []*cli.Command{
 				{
 					Name:  meta.CmdLs,
 					Usage: "列出你的模型",
 					Flags: []cli.Flag{
 						&typeFlag,
+						&baseModelFlag,
+						&keywordFlag,
+						&sortFlag,
+						&currentFlag,
+						&pageSizeFlag,
+						&webFlag,
+						&outputFlag,
 					},
 					Action: ListModel,
 				},
 				{
-					Name:  meta.CmdRm,
-					Usage: "删除你的模型",
+					Name:      meta.CmdDetail,
+					Usage:     "查看模型详情及全部版本",
+					ArgsUsage: "<id|name>",
+					Flags: []cli.Flag{
+						&typeFlag,
+						&nameFlag,
+						&idFlag,
+						&outputFlag,
+					},
+					Action: DetailModel,
+				},
+				{
+					Name:      meta.CmdEdit,
+					Usage:     "修改版本的介绍、公开状态、基础模型或封面（不重新上传模型文件）",
+					ArgsUsage: "<id|name>",
+					Flags: []cli.Flag{
+						&typeFlag,
+						&nameFlag,
+						&idFlag,
+						&versionFlag,
+						&introFlag,
+						&introPathFlag,
+						&versionPublicFlag,
+						&baseModelFlag,
+						&coverUrlsFlag,
+						&outputFlag,
+					},
+					Action: EditModel,
+				},
+				{
+					Name:      meta.CmdPull,
+					Usage:     "下载模型版本到本地（支持断点续传，下载后按版本签名校验）",
+					ArgsUsage: "<id|name>",
 					Flags: []cli.Flag{
 						&typeFlag,
 						&nameFlag,
+						&idFlag,
+						&versionFlag,
+						&dirFlag,
+						&withCoversFlag,
+						&withIntroFlag,
+						&outputFlag,
+					},
+					Action: PullModel,
+				},
+				{
+					Name:      meta.CmdExport,
+					Usage:     "将模型导出为 upload -f 可用的 YAML 配置（写到 stdout）",
+					ArgsUsage: "<id|name>",
+					Flags: []cli.Flag{
+						&typeFlag,
+						&nameFlag,
+						&idFlag,
+					},
+					Action: ExportModel,
+				},
+				{
+					Name:      meta.CmdRm,
+					Usage:     "删除你的模型，指定 --version 时只删除该版本，指定 --glob/--regex 时批量删除",
+					ArgsUsage: "<id|name>",
+					Flags: []cli.Flag{
+						&typeFlag,
+						&nameFlag,
+						&idFlag,
+						&versionFlag,
+						&globFlag,
+						&regexFlag,
+						&yesFlag,
+						&dryRunFlag,
+						&outputFlag,
 					},
 					Action: RemoveModel,
 				},
 			},
 		},
+		{
+			Name:  meta.CmdCommit,
+			Usage: "提交文件已上传、但提交模型失败的模型（不指定 --name/--type 时提交全部）",
+			Flags: []cli.Flag{
+				&typeFlag,
+				&nameFlag,
+				&outputFlag,
+			},
+			Action: Commit,
+		},
+		{
+			Name:  meta.CmdCache,
+			Usage: "{clear} 管理本地缓存",
+			Subcommands: []*cli.Command{
+				{
+					Name:   meta.CmdClear,
+					Usage:  "清空本地文件哈希缓存",
+					Flags:  []cli.Flag{&outputFlag},
+					Action: ClearCache,
+				},
+			},
+		},
 		{
 			Name:  meta.CmdUpgrade,
 			Usage: "检查并升级 CLI 到最新版本",
@@ -122,6 +321,7 @@ func Init() *cli.App {
 					Aliases: []string{"f"},
 					Usage:   "强制升级，即使版本相同",
 				},
+				&outputFlag,
 			},
 			Action: Upgrade,
 		},
@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/cloudwego/hertz/cmd/hz/util/logs"
+	"github.com/siliconflow/bizyair-cli/config"
+	"github.com/siliconflow/bizyair-cli/lib"
+	"github.com/siliconflow/bizyair-cli/lib/actions"
+	"github.com/siliconflow/bizyair-cli/meta"
+	"github.com/urfave/cli/v2"
+)
+
+// commitOutput commit 的结构化输出
+type commitOutput struct {
+	Success bool           `json:"success"`
+	Models  []uploadOutput `json:"models"`
+}
+
+// Commit 提交上次上传成功但提交模型失败的记录
+// 未指定 --name/--type 时提交当前域名下的全部待提交记录
+func Commit(c *cli.Context) error {
+	args, err := globalArgs.Parse(c, meta.CmdCommit)
+	if err != nil {
+		return exitWithError(err, meta.LoadError)
+	}
+	setLogVerbose(args.Verbose)
+	logs.Debugf("args: %#v\n", args)
+
+	if args.Type != "" {
+		if err := lib.ValidateModelType(args.Type); err != nil {
+			return exitWithError(err, meta.LoadError)
+		}
+	}
+
+	apiKey, err := resolveApiKey(args)
+	if err != nil {
+		return exitWithError(err, meta.LoadError)
+	}
+
+	list, err := lib.ListPendingCommits()
+	if err != nil {
+		return exitWithError(lib.WithStep("读取待提交记录", err), meta.LoadError)
+	}
+	var targets []*lib.PendingCommit
+	for _, pending := range list {
+		if pending.BaseDomain != args.BaseDomain ||
+			(args.Name != "" && pending.ModelName != args.Name) ||
+			(args.Type != "" && pending.ModelType != args.Type) {
+			continue
+		}
+		targets = append(targets, pending)
+	}
+
+	out := msgOut()
+	output := commitOutput{Success: true, Models: make([]uploadOutput, 0, len(targets))}
+	if len(targets) == 0 {
+		if structuredOutput() {
+			return writeOutput(output)
+		}
+		fmt.Fprintln(out, "没有待提交的模型")
+		return nil
+	}
+
+	failed := 0
+	for _, pending := range targets {
+		result := commitPending(c, args, apiKey, pending.ModelName, pending.ModelType)
+		output.Models = append(output.Models, newUploadOutput(pending.ModelName, pending.ModelType, result))
+		if result.CanceledByUser {
+			output.Success = false
+			break
+		}
+		if result.Success {
+			fmt.Fprintf(out, "✓ 模型 '%s'（%s）提交成功，共 %d 个版本\n", pending.ModelName, pending.ModelType, result.SuccessCount)
+		} else {
+			failed++
+			output.Success = false
+			fmt.Fprintf(os.Stderr, "✗ 模型 '%s'（%s）提交失败: %v\n", pending.ModelName, pending.ModelType, combineErrors(result.Errors))
+		}
+	}
+
+	if structuredOutput() {
+		if err := writeOutput(output); err != nil {
+			return cli.Exit(err, meta.LoadError)
+		}
+	}
+	if c.Context.Err() != nil {
+		return exitCanceled("已取消，未提交的记录已保留")
+	}
+	if failed > 0 {
+		return cli.Exit(fmt.Sprintf("%d 个模型提交失败，记录已保留，可稍后重试", failed), meta.ServerError)
+	}
+	return nil
+}
+
+// commitPending 使用待提交记录提交单个模型
+func commitPending(c *cli.Context, args *config.Argument, apiKey, modelName, modelType string) actions.UploadResult {
+	return actions.ExecuteCommit(actions.CommitInput{
+		ApiKey:     apiKey,
+		BaseDomain: args.BaseDomain,
+		ModelType:  modelType,
+		ModelName:  modelName,
+		Context:    c.Context,
+	})
+}
+
+// offerResumeCommit 检查模型是否有上次提交失败的记录，决定是否跳过上传直接提交
+// 记录中上传的版本须与本次要上传的版本一致，否则忽略记录、重新上传
+// 一致时：指定 --resume-commit 则直接提交；交互终端下询问用户（默认否）；否则仅提示
+func offerResumeCommit(ctx context.Context, args *config.Argument, modelName, modelType string, versions []actions.VersionInput) bool {
+	pending, err := lib.LoadPendingCommit(args.BaseDomain, modelType, modelName)
+	if err != nil {
+		logs.Warnf("%v\n", err)
+		return false
+	}
+	if pending == nil {
+		return false
+	}
+
+	out := msgOut()
+	if ok, reason, err := matchPendingCommit(ctx, pending, versions, !args.NoHashCache); err != nil || !ok {
+		if err != nil {
+			reason = err.Error()
+		}
+		fmt.Fprintf(out, "模型 '%s'（%s）有未完成的提交，但与本次上传的版本不一致（%s），将重新上传\n", modelName, modelType, reason)
+		return false
+	}
+	if args.ResumeCommit {
+		return true
+	}
+
+	fmt.Fprintf(out, "检测到模型 '%s'（%s）有未完成的提交：%d 个版本的文件已于 %s 上传完成，与本次上传的版本一致\n",
+		modelName, modelType, len(versions), pending.CreatedAt.Format("2006-01-02 15:04:05"))
+	if !interactive() {
+		fmt.Fprintf(out, "可使用 --resume-commit 或 `bizyair %s` 直接提交，本次将重新上传\n", meta.CmdCommit)
+		return false
+	}
+	return confirm("是否跳过上传，直接提交？", false)
+}
+
+// matchPendingCommit 检查待提交记录中上传的版本与本次要上传的版本是否一致
+// 版本号（未指定版本号时按路径）与路径须相同，且本地文件签名与记录一致；不一致时返回原因
+func matchPendingCommit(ctx context.Context, pending *lib.PendingCommit, versions []actions.VersionInput, useCache bool) (bool, string, error) {
+	uploaded := pending.UploadedVersions()
+	if len(uploaded) != len(versions) {
+		return false, fmt.Sprintf("记录中有 %d 个版本，本次上传 %d 个", len(uploaded), len(versions)), nil
+	}
+	for _, ver := range versions {
+		var mv *lib.ModelVersion
+		for _, candidate := range uploaded {
+			if (ver.Version != "" && candidate.Version == ver.Version) || (ver.Version == "" && candidate.Path == ver.Path) {
+				mv = candidate
+				break
+			}
+		}
+		name := ver.Version
+		if name == "" {
+			name = ver.Path
+		}
+		if mv == nil {
+			return false, fmt.Sprintf("记录中没有版本 %s", name), nil
+		}
+		if mv.Path != ver.Path {
+			return false, fmt.Sprintf("版本 %s 的路径由 %s 变为 %s", name, mv.Path, ver.Path), nil
+		}
+		ok, _, err := matchPathSignature(ctx, ver.Path, mv.Sign, mv.Files, useCache)
+		if err != nil {
+			return false, "", fmt.Errorf("版本 %s: 计算文件签名失败: %w", name, err)
+		}
+		if !ok {
+			return false, fmt.Sprintf("版本 %s 的文件已变化", name), nil
+		}
+	}
+	return true, "", nil
+}
+
+// printPendingCommitHint 提交失败但文件已上传时，提示如何重新提交
+func printPendingCommitHint(modelName, modelType string) {
+	fmt.Fprintf(os.Stderr, "文件已全部上传，提交信息已保存。可运行 `bizyair %s -n '%s' -t %s` 重新提交，无需重新上传\n",
+		meta.CmdCommit, modelName, modelType)
+}
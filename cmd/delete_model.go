@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"context"
+
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/siliconflow/bizyair-cli/lib"
 	"github.com/siliconflow/bizyair-cli/meta"
@@ -14,7 +16,7 @@ type deleteModelDoneMsg struct {
 func deleteBizyModel(apiKey string, bizyModelId int64) tea.Cmd {
 	return func() tea.Msg {
 		client := lib.NewClient(meta.DefaultDomain, apiKey)
-		_, err := client.DeleteBizyModelById(bizyModelId)
+		_, err := client.DeleteBizyModelById(context.Background(), bizyModelId)
 		if err != nil {
 			return deleteModelDoneMsg{err: err}
 		}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/cloudwego/hertz/cmd/hz/util/logs"
+	"github.com/siliconflow/bizyair-cli/lib"
+	"github.com/siliconflow/bizyair-cli/lib/actions"
+	"github.com/siliconflow/bizyair-cli/lib/format"
+	"github.com/siliconflow/bizyair-cli/meta"
+	"github.com/urfave/cli/v2"
+)
+
+func DetailModel(c *cli.Context) error {
+	args, err := globalArgs.Parse(c, meta.CmdDetail)
+	if err != nil {
+		return exitWithError(err, meta.LoadError)
+	}
+	setLogVerbose(args.Verbose)
+	logs.Debugf("args: %#v\n", args)
+
+	if args.Type != "" {
+		if err := lib.ValidateModelType(args.Type); err != nil {
+			return exitWithError(err, meta.LoadError)
+		}
+	}
+
+	apiKey, err := resolveApiKey(args)
+	if err != nil {
+		return exitWithError(err, meta.LoadError)
+	}
+
+	modelId, err := resolveModelTarget(c, args, apiKey)
+	if err != nil {
+		return exitWithError(err, meta.LoadError)
+	}
+
+	result := actions.GetModelDetail(c.Context, apiKey, args.BaseDomain, modelId)
+	if result.Error != nil {
+		return exitWithError(result.Error, meta.ServerError)
+	}
+
+	if structuredOutput() {
+		return writeOutput(result.Detail)
+	}
+
+	printModelDetail(os.Stdout, result.Detail)
+	return nil
+}
+
+// printModelDetail 输出模型详情及其全部版本
+func printModelDetail(w io.Writer, detail *lib.BizyModelDetail) {
+	fmt.Fprintf(w, "模型 ID:   %d\n", detail.Id)
+	fmt.Fprintf(w, "名称:      %s\n", detail.Name)
+	fmt.Fprintf(w, "类型:      %s\n", detail.Type)
+	fmt.Fprintf(w, "作者:      %s\n", dash(detail.UserName))
+	fmt.Fprintf(w, "来源:      %s\n", dash(detail.Source))
+	fmt.Fprintf(w, "创建时间:  %s\n", dash(detail.CreatedAt))
+	fmt.Fprintf(w, "更新时间:  %s\n", dash(detail.UpdatedAt))
+	fmt.Fprintf(w, "统计:      %s\n", formatCounter(detail.Counter))
+	fmt.Fprintf(w, "版本数:    %d\n", len(detail.Versions))
+
+	for i, ver := range detail.Versions {
+		fmt.Fprintf(w, "\n[%d/%d] 版本 %s (id=%d)\n", i+1, len(detail.Versions), ver.Version, ver.Id)
+		fmt.Fprintf(w, "  基础模型:  %s\n", dash(ver.BaseModel))
+		fmt.Fprintf(w, "  签名:      %s\n", dash(ver.Sign))
+		fmt.Fprintf(w, "  文件名:    %s\n", dash(ver.FileName))
+		fmt.Fprintf(w, "  文件大小:  %s\n", format.FormatBytes(ver.FileSize))
+		fmt.Fprintf(w, "  可用:      %t\n", ver.Available)
+		fmt.Fprintf(w, "  公开:      %t\n", ver.Public)
+		fmt.Fprintf(w, "  统计:      %s\n", formatCounter(ver.Counter))
+		fmt.Fprintf(w, "  创建时间:  %s\n", dash(ver.CreatedAt))
+		fmt.Fprintf(w, "  更新时间:  %s\n", dash(ver.UpdatedAt))
+		if len(ver.CoverUrls) == 0 {
+			fmt.Fprintf(w, "  封面:      -\n")
+		} else {
+			fmt.Fprintf(w, "  封面:\n")
+			for _, url := range ver.CoverUrls {
+				fmt.Fprintf(w, "    - %s\n", url)
+			}
+		}
+		if strings.TrimSpace(ver.Intro) == "" {
+			fmt.Fprintf(w, "  介绍:      -\n")
+		} else {
+			fmt.Fprintf(w, "  介绍:\n")
+			for _, line := range strings.Split(strings.TrimSpace(ver.Intro), "\n") {
+				fmt.Fprintf(w, "    %s\n", line)
+			}
+		}
+	}
+}
+
+// formatCounter 格式化模型统计信息
+func formatCounter(counter lib.ModelCounter) string {
+	return fmt.Sprintf("使用 %d / 复刻 %d / 点赞 %d / 下载 %d / 浏览 %d",
+		counter.UsedCount, counter.ForkedCount, counter.LikedCount, counter.DownloadedCount, counter.ViewCount)
+}
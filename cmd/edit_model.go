@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/cloudwego/hertz/cmd/hz/util/logs"
+	"github.com/siliconflow/bizyair-cli/lib"
+	"github.com/siliconflow/bizyair-cli/lib/actions"
+	"github.com/siliconflow/bizyair-cli/meta"
+	"github.com/urfave/cli/v2"
+)
+
+// editModelOutput model edit 的结构化输出
+type editModelOutput struct {
+	Success   bool              `json:"success"`
+	ModelId   int64             `json:"model_id"`
+	ModelName string            `json:"model_name"`
+	ModelType string            `json:"model_type"`
+	Version   *lib.ModelVersion `json:"version"`
+}
+
+// EditModel 修改版本的介绍、公开状态、基础模型或封面，不重新上传模型文件
+func EditModel(c *cli.Context) error {
+	args, err := globalArgs.Parse(c, meta.CmdEdit)
+	if err != nil {
+		return exitWithError(err, meta.LoadError)
+	}
+	setLogVerbose(args.Verbose)
+	logs.Debugf("args: %#v\n", args)
+
+	if err := checkTrailingArgs(c); err != nil {
+		return exitWithError(err, meta.LoadError)
+	}
+	if args.Type != "" {
+		if err := lib.ValidateModelType(args.Type); err != nil {
+			return exitWithError(err, meta.LoadError)
+		}
+	}
+
+	input := actions.EditVersionInput{
+		BaseDomain: args.BaseDomain,
+		Version:    getStringAt(args.ModelVersion, 0, ""),
+		CoverUrl:   getStringAt(args.CoverUrls, 0, ""),
+		Context:    c.Context,
+		OnCoverStatus: func(status, message string) {
+			if status == "fallback" {
+				fmt.Fprintf(os.Stderr, "⚠ 警告: %s\n", message)
+			}
+		},
+	}
+
+	// 优先从文件读取 intro，其次使用直接提供的 intro
+	if introPath := getStringAt(args.IntroPath, 0, ""); introPath != "" {
+		if err := lib.ValidateIntroFile(introPath); err != nil {
+			return exitWithError(fmt.Errorf("intro 文件验证失败: %w", err), meta.LoadError)
+		}
+		content, err := lib.ReadIntroFile(introPath)
+		if err != nil {
+			return exitWithError(fmt.Errorf("读取 intro 文件失败: %w", err), meta.LoadError)
+		}
+		input.Introduction = &content
+	} else if c.IsSet("intro") {
+		intro := getStringAt(args.Intro, 0, "")
+		input.Introduction = &intro
+	}
+	if c.IsSet("public") {
+		public, err := strconv.ParseBool(getStringAt(args.VersionPublic, 0, ""))
+		if err != nil {
+			return exitWithError(fmt.Errorf("public 参数无效，请使用 true 或 false"), meta.LoadError)
+		}
+		input.Public = &public
+	}
+	if c.IsSet("base") {
+		base := getStringAt(args.BaseModel, 0, "")
+		input.BaseModel = &base
+	}
+
+	apiKey, err := resolveApiKey(args)
+	if err != nil {
+		return exitWithError(err, meta.LoadError)
+	}
+	input.ApiKey = apiKey
+
+	input.ModelId, err = resolveModelTarget(c, args, apiKey)
+	if err != nil {
+		return exitWithError(err, meta.LoadError)
+	}
+
+	result := actions.ExecuteEditVersion(input)
+	if result.Error != nil {
+		return exitWithError(result.Error, meta.ServerError)
+	}
+
+	if structuredOutput() {
+		return writeOutput(editModelOutput{
+			Success:   true,
+			ModelId:   result.ModelId,
+			ModelName: result.ModelName,
+			ModelType: result.ModelType,
+			Version:   result.Version,
+		})
+	}
+	fmt.Fprintf(os.Stdout, "✓ 模型 '%s'（%s）版本 %s 已更新\n", result.ModelName, result.ModelType, result.Version.Version)
+	return nil
+}
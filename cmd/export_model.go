@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/cloudwego/hertz/cmd/hz/util/logs"
+	"github.com/siliconflow/bizyair-cli/config"
+	"github.com/siliconflow/bizyair-cli/lib"
+	"github.com/siliconflow/bizyair-cli/lib/actions"
+	"github.com/siliconflow/bizyair-cli/meta"
+	"github.com/urfave/cli/v2"
+)
+
+// ExportModel 将线上模型导出为 upload -f 可用的 YAML 配置，写到 stdout
+func ExportModel(c *cli.Context) error {
+	args, err := globalArgs.Parse(c, meta.CmdExport)
+	if err != nil {
+		return exitWithError(err, meta.LoadError)
+	}
+	setLogVerbose(args.Verbose)
+	logs.Debugf("args: %#v\n", args)
+
+	if err := checkTrailingArgs(c); err != nil {
+		return exitWithError(err, meta.LoadError)
+	}
+	if args.Type != "" {
+		if err := lib.ValidateModelType(args.Type); err != nil {
+			return exitWithError(err, meta.LoadError)
+		}
+	}
+
+	apiKey, err := resolveApiKey(args)
+	if err != nil {
+		return exitWithError(err, meta.LoadError)
+	}
+	modelId, err := resolveModelTarget(c, args, apiKey)
+	if err != nil {
+		return exitWithError(err, meta.LoadError)
+	}
+	result := actions.GetModelDetail(c.Context, apiKey, args.BaseDomain, modelId)
+	if result.Error != nil {
+		return exitWithError(result.Error, meta.ServerError)
+	}
+
+	model := config.NewYamlModelFromDetail(*result.Detail)
+	// 导出的内容写到 stdout，提示写到 stderr，便于重定向到文件
+	for i, ver := range model.Versions {
+		if ver.ModelPath == "" {
+			fmt.Fprintf(os.Stderr, "⚠ 版本 %s 没有文件名，请手动填写 model_path\n", ver.Name)
+		}
+		if ver.CoverUrl == "" {
+			fmt.Fprintf(os.Stderr, "⚠ 版本 %s 没有封面，请手动填写 cover_path 或 cover_url\n", ver.Name)
+		}
+		if covers := result.Detail.Versions[i].CoverUrls; len(covers) > 1 {
+			fmt.Fprintf(os.Stderr, "⚠ 版本 %s 有 %d 张封面，cover_url 只能填写一张，已导出第一张，其余 %d 张未导出\n",
+				ver.Name, len(covers), len(covers)-1)
+		}
+	}
+	if err := config.WriteYamlConfig(os.Stdout, &config.YamlConfig{Models: []config.YamlModel{model}}); err != nil {
+		return exitWithError(err, meta.LoadError)
+	}
+	fmt.Fprintf(os.Stderr, "已导出模型 '%s'（%s）的 %d 个版本，model_path 为相对于 YAML 文件所在目录的文件名，可通过 `bizyair model pull -o <YAML 所在目录>` 下载\n",
+		model.Name, model.Type, len(model.Versions))
+	return nil
+}
@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/siliconflow/bizyair-cli/config"
+	"github.com/siliconflow/bizyair-cli/lib"
+	"github.com/siliconflow/bizyair-cli/lib/actions"
+)
+
+// openLockFile 读取 YAML 对应的锁文件
+// 锁文件记录的是其他域名时不使用（--frozen 时报错），返回 nil
+func openLockFile(yamlPath, baseDomain string, frozen bool) (*config.LockFile, error) {
+	lock, err := config.LoadLockFile(yamlPath)
+	if err != nil {
+		return nil, err
+	}
+	if lock.BaseDomain != "" && lock.BaseDomain != baseDomain {
+		if frozen {
+			return nil, fmt.Errorf("锁文件 %s 记录的是 %s 上的模型，与当前域名 %s 不一致", lock.Path(), lock.BaseDomain, baseDomain)
+		}
+		fmt.Fprintf(os.Stderr, "⚠ 锁文件 %s 记录的是 %s 上的模型，本次不读取也不更新\n", lock.Path(), lock.BaseDomain)
+		return nil, nil
+	}
+	if frozen && len(lock.Models) == 0 {
+		return nil, fmt.Errorf("--frozen 需要锁文件 %s，请先不带 --frozen 上传一次", lock.Path())
+	}
+	lock.BaseDomain = baseDomain
+	return lock, nil
+}
+
+// lockRelPath 返回版本路径相对于 YAML 所在目录的路径，用于锁文件
+func lockRelPath(yamlDir, path string) string {
+	absDir, err := filepath.Abs(yamlDir)
+	if err != nil {
+		return filepath.ToSlash(path)
+	}
+	rel, err := filepath.Rel(absDir, path)
+	if err != nil {
+		return filepath.ToSlash(path)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// checkLockedVersion 检查版本是否已按锁文件发布且本地文件未变化
+// 不一致时返回原因
+func checkLockedVersion(ctx context.Context, lm *config.LockModel, name, relPath, path string, useCache bool) (bool, string, error) {
+	if lm == nil {
+		return false, "锁文件中没有该模型", nil
+	}
+	lv := lm.Version(name)
+	if lv == nil {
+		return false, "锁文件中没有该版本", nil
+	}
+	if lv.Path != relPath {
+		return false, fmt.Sprintf("路径由 %s 变为 %s", lv.Path, relPath), nil
+	}
+	if lv.Sign == "" && len(lv.Files) == 0 {
+		return false, "锁文件中没有文件签名", nil
+	}
+
+	ok, file, err := matchPathSignature(ctx, path, lv.Sign, lv.Files, useCache)
+	if err != nil || ok {
+		return ok, "", err
+	}
+	if file != "" {
+		return false, fmt.Sprintf("目录中的文件 %s 与锁文件不一致", file), nil
+	}
+	return false, "文件签名与锁文件不一致", nil
+}
+
+// matchPathSignature 检查本地文件（或目录中各文件）的签名是否与记录一致
+// 目录中的某个文件不一致时返回其相对路径
+func matchPathSignature(ctx context.Context, path, sign string, files []*lib.ModelFile, useCache bool) (bool, string, error) {
+	localSign, localFiles, err := lib.PathSignature(ctx, path, useCache)
+	if err != nil {
+		return false, "", err
+	}
+	if localFiles == nil {
+		return localSign == sign, "", nil
+	}
+	recorded := make(map[string]string, len(files))
+	for _, f := range files {
+		recorded[f.Path] = f.Sign
+	}
+	for _, f := range localFiles {
+		if recorded[f.Path] != f.Sign {
+			return false, f.Path, nil
+		}
+	}
+	return len(localFiles) == len(files), "", nil
+}
+
+// refreshLockModel 按远端详情更新锁文件中的模型记录，只保留配置中声明的版本
+// paths 为版本号到路径（相对于 YAML 所在目录）的映射；published 为本次上传的版本，目录版本的文件签名只能从中获得
+func refreshLockModel(lock *config.LockFile, detail *lib.BizyModelDetail, paths map[string]string, published []*lib.ModelVersion) {
+	now := time.Now()
+	previous := lock.Model(detail.Name, detail.Type)
+	model := &config.LockModel{Name: detail.Name, Type: detail.Type, ModelId: detail.Id, UpdatedAt: now}
+
+	uploaded := make(map[string]*lib.ModelVersion, len(published))
+	for _, ver := range published {
+		uploaded[ver.Version] = ver
+	}
+	for _, ver := range detail.Versions {
+		path, ok := paths[ver.Version]
+		if !ok {
+			continue
+		}
+		lv := &config.LockVersion{
+			Version:   ver.Version,
+			VersionId: ver.Id,
+			Path:      path,
+			Sign:      ver.Sign,
+			CoverUrls: ver.CoverUrls,
+			CreatedAt: ver.CreatedAt,
+			UpdatedAt: now,
+		}
+		if mv, ok := uploaded[ver.Version]; ok {
+			if lv.Sign == "" {
+				lv.Sign = mv.Sign
+			}
+			lv.Files = mv.Files
+		} else if previous != nil {
+			// 本次未上传的版本沿用原记录的目录文件签名与记录时间
+			if prev := previous.Version(ver.Version); prev != nil && prev.Path == path {
+				lv.Files = prev.Files
+				lv.UpdatedAt = prev.UpdatedAt
+			}
+		}
+		model.Versions = append(model.Versions, lv)
+	}
+	lock.SetModel(model)
+}
+
+// fetchModelDetail 按名称和类型查询模型详情
+func fetchModelDetail(ctx context.Context, apiKey, baseDomain, name, modelType string) (*lib.BizyModelDetail, error) {
+	matched, err := actions.FindModelsByName(ctx, apiKey, baseDomain, name, modelType)
+	if err != nil {
+		return nil, err
+	}
+	if len(matched) == 0 {
+		return nil, fmt.Errorf("未找到模型 '%s'（%s）", name, modelType)
+	}
+	result := actions.GetModelDetail(ctx, apiKey, baseDomain, matched[0].Id)
+	return result.Detail, result.Error
+}
@@ -19,15 +19,18 @@ func Login(c *cli.Context) error {
 	logs.Debugf("args: %#v\n", args)
 
 	if args.ApiKey == "" {
-		return cli.Exit(fmt.Errorf("api key is required, you can specify \"--api_key\" or environment variable \"%s\" to set", meta.EnvAPIKey), meta.LoadError)
+		return exitWithError(fmt.Errorf("api key is required, you can specify \"--api_key\" or environment variable \"%s\" to set", meta.EnvAPIKey), meta.LoadError)
 	}
 
 	// 调用统一的登录业务逻辑
-	result := actions.ExecuteLogin(args.ApiKey)
+	result := actions.ExecuteLogin(c.Context, args.ApiKey)
 	if !result.Success {
-		return cli.Exit(result.Error, meta.LoadError)
+		return exitWithError(result.Error, meta.LoadError)
 	}
 
+	if structuredOutput() {
+		return writeOutput(successOutput{Success: true, Message: "Login successfully"})
+	}
 	fmt.Fprintln(os.Stdout, "Login successfully")
 	return nil
 }
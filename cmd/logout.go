@@ -21,9 +21,12 @@ func Logout(c *cli.Context) error {
 	// 调用统一的登出业务逻辑
 	err = actions.ExecuteLogout()
 	if err != nil {
-		return cli.Exit(err, meta.LoadError)
+		return exitWithError(err, meta.LoadError)
 	}
 
+	if structuredOutput() {
+		return writeOutput(successOutput{Success: true, Message: "Logged out successfully"})
+	}
 	fmt.Fprintln(os.Stdout, "Logged out successfully")
 	return nil
 }
@@ -3,9 +3,13 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
+	"text/tabwriter"
 
 	"github.com/cloudwego/hertz/cmd/hz/util/logs"
+	"github.com/samber/lo"
 	"github.com/siliconflow/bizyair-cli/lib"
+	"github.com/siliconflow/bizyair-cli/lib/actions"
 	"github.com/siliconflow/bizyair-cli/meta"
 	"github.com/urfave/cli/v2"
 )
@@ -13,22 +17,113 @@ import (
 func ListModel(c *cli.Context) error {
 	args, err := globalArgs.Parse(c, meta.CmdLs)
 	if err != nil {
-		return cli.Exit(err, meta.LoadError)
+		return exitWithError(err, meta.LoadError)
 	}
 	setLogVerbose(args.Verbose)
 	logs.Debugf("args: %#v\n", args)
 
-	// 打开浏览器查看"我的模型"
+	if args.Web {
+		return openMyModelsPage()
+	}
+
+	if args.Type != "" {
+		if err := lib.ValidateModelType(args.Type); err != nil {
+			return exitWithError(err, meta.LoadError)
+		}
+	}
+	for _, base := range args.BaseModel {
+		if err := lib.ValidateBaseModel(base); err != nil {
+			return exitWithError(err, meta.LoadError)
+		}
+	}
+
+	// 获取 API Key
+	apiKey, err := resolveApiKey(args)
+	if err != nil {
+		return exitWithError(err, meta.LoadError)
+	}
+
+	result := actions.ListAllModels(actions.ListModelsInput{
+		ApiKey:     apiKey,
+		BaseDomain: args.BaseDomain,
+		ModelType:  args.Type,
+		BaseModels: args.BaseModel,
+		Keyword:    args.Keyword,
+		Sort:       args.Sort,
+		Current:    args.Current,
+		PageSize:   args.PageSize,
+		Context:    c.Context,
+	})
+	if result.Error != nil {
+		return exitWithError(result.Error, meta.ServerError)
+	}
+
+	if structuredOutput() {
+		return writeOutput(modelListOutput{Total: result.Total, Models: result.Models})
+	}
+
+	printModelTable(result.Models)
+	fmt.Fprintf(os.Stdout, "\n共 %d 个模型\n", result.Total)
+	return nil
+}
+
+// modelListOutput model ls 的结构化输出
+type modelListOutput struct {
+	Total  int                  `json:"total"`
+	Models []*lib.BizyModelInfo `json:"models"`
+}
+
+// modelPageOutput 打开模型页面的结构化输出
+type modelPageOutput struct {
+	Success bool   `json:"success"`
+	URL     string `json:"url"`
+}
+
+// openMyModelsPage 在浏览器中打开"我的模型"页面
+func openMyModelsPage() error {
 	msg, err := lib.OpenBrowser(lib.MyModelsURL)
 	if err != nil {
 		// 如果无法打开浏览器，提示用户手动访问
 		fmt.Fprintf(os.Stderr, "无法打开浏览器: %v\n", err)
-		fmt.Fprintf(os.Stdout, "请在浏览器中访问: %s\n", lib.MyModelsURL)
-		return cli.Exit(err, meta.LoadError)
+		fmt.Fprintf(msgOut(), "请在浏览器中访问: %s\n", lib.MyModelsURL)
+		return exitWithError(err, meta.LoadError)
 	}
 
 	// 成功打开浏览器
+	if structuredOutput() {
+		return writeOutput(modelPageOutput{Success: true, URL: lib.MyModelsURL})
+	}
 	fmt.Fprintln(os.Stdout, msg)
 	fmt.Fprintf(os.Stdout, "访问: %s\n", lib.MyModelsURL)
 	return nil
 }
+
+// printModelTable 以表格形式输出模型列表
+func printModelTable(models []*lib.BizyModelInfo) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tTYPE\tVERSIONS\tBASE MODELS\tUSED\tFORKED\tLIKED\tDOWNLOADED\tVIEWS\tUPDATED AT")
+	for _, model := range models {
+		versions := make([]string, 0, len(model.Versions))
+		baseModels := make([]string, 0, len(model.Versions))
+		for _, ver := range model.Versions {
+			versions = append(versions, ver.Version)
+			if ver.BaseModel != "" && !lo.Contains(baseModels, ver.BaseModel) {
+				baseModels = append(baseModels, ver.BaseModel)
+			}
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%d\t%d\t%d\t%d\t%d\t%s\n",
+			model.Id,
+			model.Name,
+			model.Type,
+			dash(strings.Join(versions, ",")),
+			dash(strings.Join(baseModels, ",")),
+			model.Counter.UsedCount,
+			model.Counter.ForkedCount,
+			model.Counter.LikedCount,
+			model.Counter.DownloadedCount,
+			model.Counter.ViewCount,
+			dash(model.UpdatedAt),
+		)
+	}
+	w.Flush()
+}
@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/siliconflow/bizyair-cli/lib"
+	"github.com/siliconflow/bizyair-cli/meta"
+	"github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// validateOutputFormat 校验 --output 参数
+func validateOutputFormat(output string) error {
+	switch output {
+	case meta.OutputText, meta.OutputJSON, meta.OutputYAML:
+		return nil
+	default:
+		return fmt.Errorf("不支持的输出格式 [%s]，仅支持 %s、%s", output, meta.OutputJSON, meta.OutputYAML)
+	}
+}
+
+// structuredOutput 是否为结构化输出模式（json/yaml）
+func structuredOutput() bool {
+	return globalArgs.Output == meta.OutputJSON || globalArgs.Output == meta.OutputYAML
+}
+
+// msgOut 返回提示信息与进度的输出目标
+// 结构化输出模式下写到 stderr，保证 stdout 只有一份结构化文档
+func msgOut() io.Writer {
+	if structuredOutput() {
+		return os.Stderr
+	}
+	return os.Stdout
+}
+
+// errorOutput 结构化输出中的错误对象
+type errorOutput struct {
+	Step    string `json:"step,omitempty"`
+	Message string `json:"message"`
+}
+
+func newErrorOutput(err error) *errorOutput {
+	if err == nil {
+		return nil
+	}
+	out := &errorOutput{Message: err.Error()}
+	var stepErr *lib.StepError
+	if errors.As(err, &stepErr) {
+		out.Step = stepErr.Step
+		out.Message = stepErr.Err.Error()
+	}
+	return out
+}
+
+func newErrorOutputs(errs []error) []*errorOutput {
+	outs := make([]*errorOutput, 0, len(errs))
+	for _, err := range errs {
+		if err != nil {
+			outs = append(outs, newErrorOutput(err))
+		}
+	}
+	return outs
+}
+
+// failureOutput 命令失败时输出的结构化文档
+type failureOutput struct {
+	Success bool         `json:"success"`
+	Error   *errorOutput `json:"error"`
+}
+
+// successOutput 无额外数据的命令成功时输出的结构化文档
+type successOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message,omitempty"`
+}
+
+// exitWithError 以指定退出码结束命令
+// 结构化输出模式下先把错误（含 StepError 的步骤）写为结构化文档
+func exitWithError(err error, code int) error {
+	if !structuredOutput() {
+		return cli.Exit(err, code)
+	}
+	if werr := writeOutput(failureOutput{Success: false, Error: newErrorOutput(err)}); werr != nil {
+		return cli.Exit(werr, meta.LoadError)
+	}
+	return cli.Exit("", code)
+}
+
+// exitCanceled 用户取消时以 CancelError 结束命令
+// 结构化输出模式下结果已写到 stdout，不再输出提示
+func exitCanceled(message string) error {
+	if structuredOutput() {
+		return cli.Exit("", meta.CancelError)
+	}
+	return cli.Exit(message, meta.CancelError)
+}
+
+// writeOutput 将结果按 --output 指定的格式写到 stdout
+// YAML 由 JSON 转换而来，保证两种格式的字段名一致（均沿用 json tag）
+func writeOutput(v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("序列化输出失败: %w", err)
+	}
+
+	if globalArgs.Output == meta.OutputYAML {
+		var node yaml.Node
+		if err := yaml.Unmarshal(data, &node); err != nil {
+			return fmt.Errorf("序列化输出失败: %w", err)
+		}
+		resetYamlStyle(&node)
+		enc := yaml.NewEncoder(os.Stdout)
+		enc.SetIndent(2)
+		if err := enc.Encode(&node); err != nil {
+			return fmt.Errorf("序列化输出失败: %w", err)
+		}
+		return enc.Close()
+	}
+
+	_, err = fmt.Fprintln(os.Stdout, string(data))
+	return err
+}
+
+// resetYamlStyle 清除从 JSON 解析得到的引号/流式风格，输出常规块状 YAML
+func resetYamlStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetYamlStyle(child)
+	}
+}
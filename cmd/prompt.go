@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mattn/go-isatty"
+)
+
+// stdinReader 多次提问共用，避免丢失已缓冲的输入
+var stdinReader = bufio.NewReader(os.Stdin)
+
+// interactive 是否可以向用户提问
+// 结构化输出或标准输入不是终端（管道、CI）时不提问
+func interactive() bool {
+	if structuredOutput() {
+		return false
+	}
+	fd := os.Stdin.Fd()
+	return isatty.IsTerminal(fd) || isatty.IsCygwinTerminal(fd)
+}
+
+// confirm 询问用户是否继续，直接回车时返回 defaultYes
+func confirm(question string, defaultYes bool) bool {
+	hint := "[y/N]"
+	if defaultYes {
+		hint = "[Y/n]"
+	}
+	fmt.Fprintf(msgOut(), "%s %s ", question, hint)
+
+	line, err := stdinReader.ReadString('\n')
+	if err != nil && line == "" {
+		return false
+	}
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "":
+		return defaultYes
+	case "y", "yes":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/cloudwego/hertz/cmd/hz/util/logs"
+	"github.com/siliconflow/bizyair-cli/lib"
+	"github.com/siliconflow/bizyair-cli/lib/actions"
+	"github.com/siliconflow/bizyair-cli/lib/format"
+	"github.com/siliconflow/bizyair-cli/meta"
+	"github.com/urfave/cli/v2"
+)
+
+// pullModelOutput model pull 的结构化输出
+type pullModelOutput struct {
+	Success    bool     `json:"success"`
+	ModelId    int64    `json:"model_id"`
+	ModelName  string   `json:"model_name"`
+	ModelType  string   `json:"model_type"`
+	Version    string   `json:"version"`
+	FilePath   string   `json:"file_path"`
+	FileSize   int64    `json:"file_size"`
+	Verified   bool     `json:"verified"`
+	Skipped    bool     `json:"skipped,omitempty"`
+	CoverPaths []string `json:"cover_paths,omitempty"`
+	IntroPath  string   `json:"intro_path,omitempty"`
+}
+
+// PullModel 下载模型版本到本地，支持断点续传并按版本签名校验
+func PullModel(c *cli.Context) error {
+	args, err := globalArgs.Parse(c, meta.CmdPull)
+	if err != nil {
+		return exitWithError(err, meta.LoadError)
+	}
+	setLogVerbose(args.Verbose)
+	logs.Debugf("args: %#v\n", args)
+
+	if err := checkTrailingArgs(c); err != nil {
+		return exitWithError(err, meta.LoadError)
+	}
+	if args.Type != "" {
+		if err := lib.ValidateModelType(args.Type); err != nil {
+			return exitWithError(err, meta.LoadError)
+		}
+	}
+
+	apiKey, err := resolveApiKey(args)
+	if err != nil {
+		return exitWithError(err, meta.LoadError)
+	}
+	modelId, err := resolveModelTarget(c, args, apiKey)
+	if err != nil {
+		return exitWithError(err, meta.LoadError)
+	}
+
+	out := msgOut()
+	inProgress := false
+	endProgress := func() {
+		if inProgress {
+			fmt.Fprintln(out)
+			inProgress = false
+		}
+	}
+	result := actions.ExecutePull(actions.PullInput{
+		ApiKey:     apiKey,
+		BaseDomain: args.BaseDomain,
+		ModelId:    modelId,
+		Version:    getStringAt(args.ModelVersion, 0, ""),
+		Dir:        args.Dir,
+		WithCovers: args.WithCovers,
+		WithIntro:  args.WithIntro,
+		Context:    c.Context,
+		OnStatus: func(message string) {
+			endProgress()
+			fmt.Fprintln(out, message)
+		},
+		OnDownloadProgress: func(downloaded, total int64) {
+			inProgress = true
+			fmt.Fprintf(out, "\r下载进度: %s %.1f%% (%s / %s)", renderProgressBar(float64(downloaded)/float64(total)),
+				float64(downloaded)/float64(total)*100, format.FormatBytes(downloaded), format.FormatBytes(total))
+		},
+		OnVerifyProgress: func(consumed, total int64) {
+			if total <= 0 {
+				return
+			}
+			inProgress = true
+			fmt.Fprintf(out, "\r校验进度: %s %.1f%%", renderProgressBar(float64(consumed)/float64(total)), float64(consumed)/float64(total)*100)
+		},
+	})
+	endProgress()
+	if result.Error != nil {
+		if errors.Is(result.Error, context.Canceled) {
+			fmt.Fprintln(out, "下载已取消")
+			if result.FilePath != "" {
+				fmt.Fprintf(out, "已下载的部分保存在 %s，重新执行同一命令可继续下载\n", result.FilePath+meta.DownloadPartSuffix)
+			}
+			return nil
+		}
+		return exitWithError(result.Error, meta.ServerError)
+	}
+
+	if structuredOutput() {
+		return writeOutput(pullModelOutput{
+			Success:    true,
+			ModelId:    result.ModelId,
+			ModelName:  result.ModelName,
+			ModelType:  result.ModelType,
+			Version:    result.Version,
+			FilePath:   result.FilePath,
+			FileSize:   result.FileSize,
+			Verified:   result.Verified,
+			Skipped:    result.Skipped,
+			CoverPaths: result.CoverPaths,
+			IntroPath:  result.IntroPath,
+		})
+	}
+	if result.Skipped {
+		fmt.Fprintf(os.Stdout, "✓ %s 已存在且校验通过，跳过下载\n", result.FilePath)
+	} else {
+		fmt.Fprintf(os.Stdout, "✓ 模型 '%s'（%s）版本 %s 已下载到 %s\n", result.ModelName, result.ModelType, result.Version, result.FilePath)
+	}
+	for _, cover := range result.CoverPaths {
+		fmt.Fprintf(os.Stdout, "  封面: %s\n", cover)
+	}
+	if result.IntroPath != "" {
+		fmt.Fprintf(os.Stdout, "  介绍: %s\n", result.IntroPath)
+	}
+	return nil
+}
@@ -2,69 +2,344 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"os"
 
 	"github.com/cloudwego/hertz/cmd/hz/util/logs"
+	"github.com/siliconflow/bizyair-cli/config"
 	"github.com/siliconflow/bizyair-cli/lib"
 	"github.com/siliconflow/bizyair-cli/lib/actions"
+	"github.com/siliconflow/bizyair-cli/lib/format"
 	"github.com/siliconflow/bizyair-cli/meta"
 	"github.com/urfave/cli/v2"
 )
 
+// removeModelOutput model rm 的结构化输出
+type removeModelOutput struct {
+	Success    bool         `json:"success"`
+	DryRun     bool         `json:"dry_run,omitempty"`
+	ModelId    int64        `json:"model_id"`
+	ModelName  string       `json:"model_name"`
+	ModelType  string       `json:"model_type"`
+	Version    string       `json:"version,omitempty"`      // 仅删除单个版本时返回
+	NewModelId int64        `json:"new_model_id,omitempty"` // 删除单个版本后重新提交得到的模型 ID
+	Warning    string       `json:"warning,omitempty"`
+	Error      *errorOutput `json:"error,omitempty"`
+}
+
+// removeVersionWarning 删除部分版本时的提示，交互、--yes 与结构化输出中均给出
+const removeVersionWarning = "删除版本会先删除整个模型，再以原有文件重新提交其余版本，模型 ID 与使用统计将随之变化"
+
+// bulkRemoveOutput 按 glob/regex 批量删除的结构化输出
+type bulkRemoveOutput struct {
+	Success bool                `json:"success"`
+	DryRun  bool                `json:"dry_run,omitempty"`
+	Pattern string              `json:"pattern"`
+	Matched int                 `json:"matched"`
+	Removed int                 `json:"removed"`
+	Failed  int                 `json:"failed"`
+	Models  []removeModelOutput `json:"models"`
+}
+
+// removeTarget 待删除模型的展示信息
+type removeTarget struct {
+	Id       int64
+	Name     string
+	Type     string
+	Counter  lib.ModelCounter
+	Versions []removeTargetVersion
+}
+
+type removeTargetVersion struct {
+	Version  string
+	FileName string
+	FileSize int64
+	Counter  lib.ModelCounter
+}
+
 func RemoveModel(c *cli.Context) error {
 	args, err := globalArgs.Parse(c, meta.CmdRm)
 	if err != nil {
-		return cli.Exit(err, meta.LoadError)
+		return exitWithError(err, meta.LoadError)
 	}
 	setLogVerbose(args.Verbose)
 	logs.Debugf("args: %#v\n", args)
 
-	if err := lib.ValidateModelType(args.Type); err != nil {
-		return cli.Exit(err, meta.LoadError)
+	if err := checkTrailingArgs(c); err != nil {
+		return exitWithError(err, meta.LoadError)
 	}
-
-	// 获取API Key
-	var apiKey string
-	if args.ApiKey != "" {
-		apiKey = args.ApiKey
-	} else {
-		apiKey, err = lib.NewSfFolder().GetKey()
-		if err != nil {
-			return cli.Exit(err, meta.LoadError)
+	if args.Type != "" {
+		if err := lib.ValidateModelType(args.Type); err != nil {
+			return exitWithError(err, meta.LoadError)
 		}
 	}
+	if args.Glob != "" || args.Regex != "" {
+		return removeModelsByPattern(c, args)
+	}
+	if version := getStringAt(args.ModelVersion, 0, ""); version != "" {
+		return removeModelVersion(c, args, version)
+	}
 
-	// 先查找模型以获取ID
-	listInput := actions.ListModelsInput{
+	apiKey, err := resolveApiKey(args)
+	if err != nil {
+		return exitWithError(err, meta.LoadError)
+	}
+
+	// 按 ID 或名称（及类型）在所有分页中精确查找
+	modelId, err := resolveModelTarget(c, args, apiKey)
+	if err != nil {
+		return exitWithError(err, meta.LoadError)
+	}
+	detailResult := actions.GetModelDetail(c.Context, apiKey, args.BaseDomain, modelId)
+	if detailResult.Error != nil {
+		return exitWithError(detailResult.Error, meta.ServerError)
+	}
+	detail := detailResult.Detail
+	if err := checkModelType(detail, args.Type); err != nil {
+		return exitWithError(err, meta.LoadError)
+	}
+	output := removeModelOutput{Success: true, DryRun: args.DryRun, ModelId: detail.Id, ModelName: detail.Name, ModelType: detail.Type}
+
+	out := msgOut()
+	fmt.Fprintln(out, "将删除以下模型：")
+	printRemoveTarget(out, newRemoveTargetFromDetail(detail))
+	if args.DryRun {
+		return finishDryRun(output)
+	}
+	if ok, err := confirmRemoval(args, "确认删除该模型？"); err != nil {
+		return exitWithError(err, meta.LoadError)
+	} else if !ok {
+		fmt.Fprintln(out, "已取消")
+		return nil
+	}
+
+	// 调用统一的删除逻辑
+	result := actions.DeleteModel(c.Context, apiKey, args.BaseDomain, detail.Id)
+	if !result.Success {
+		return exitWithError(result.Error, meta.ServerError)
+	}
+
+	if structuredOutput() {
+		return writeOutput(output)
+	}
+	fmt.Fprintln(os.Stdout, "Model removed successfully.")
+	return nil
+}
+
+// removeModelsByPattern 删除名称匹配 glob/regex 的全部模型（可用 --type 限定类型）
+func removeModelsByPattern(c *cli.Context, args *config.Argument) error {
+	if c.Args().Present() || args.Name != "" || len(args.ModelVersion) > 0 {
+		return exitWithError(lib.NewValidationError("按 glob/regex 批量删除时不能同时指定模型 ID、名称或版本"), meta.LoadError)
+	}
+	matcher, err := lib.NewNameMatcher(args.Glob, args.Regex)
+	if err != nil {
+		return exitWithError(err, meta.LoadError)
+	}
+
+	apiKey, err := resolveApiKey(args)
+	if err != nil {
+		return exitWithError(err, meta.LoadError)
+	}
+
+	listResult := actions.ListAllModels(actions.ListModelsInput{
 		ApiKey:     apiKey,
 		BaseDomain: args.BaseDomain,
 		ModelType:  args.Type,
-		Keyword:    args.Name,
-	}
-	listResult := actions.ListModels(listInput)
+		Context:    c.Context,
+	})
 	if listResult.Error != nil {
-		return cli.Exit(listResult.Error, meta.ServerError)
+		return exitWithError(listResult.Error, meta.ServerError)
 	}
-
-	// 查找匹配的模型
-	var modelId int64
+	var matched []*lib.BizyModelInfo
 	for _, model := range listResult.Models {
-		if model.Name == args.Name {
-			modelId = model.Id
-			break
+		if matcher.Match(model.Name) {
+			matched = append(matched, model)
 		}
 	}
 
-	if modelId == 0 {
-		return cli.Exit(fmt.Errorf("model '%s' not found", args.Name), meta.LoadError)
+	output := bulkRemoveOutput{
+		Success: true,
+		DryRun:  args.DryRun,
+		Pattern: matcher.String(),
+		Matched: len(matched),
+		Models:  make([]removeModelOutput, 0, len(matched)),
+	}
+	out := msgOut()
+	if len(matched) == 0 {
+		if structuredOutput() {
+			return writeOutput(output)
+		}
+		fmt.Fprintf(out, "没有名称匹配 %s 的模型\n", matcher)
+		return nil
 	}
 
-	// 调用统一的删除逻辑
-	result := actions.DeleteModel(apiKey, args.BaseDomain, modelId)
+	fmt.Fprintf(out, "名称匹配 %s 的模型共 %d 个：\n", matcher, len(matched))
+	for _, model := range matched {
+		printRemoveTarget(out, newRemoveTargetFromInfo(model))
+	}
+	if args.DryRun {
+		for _, model := range matched {
+			output.Models = append(output.Models, removeModelOutput{Success: true, DryRun: true, ModelId: model.Id, ModelName: model.Name, ModelType: model.Type})
+		}
+		return finishDryRun(output)
+	}
+	if ok, err := confirmRemoval(args, fmt.Sprintf("确认删除以上 %d 个模型？", len(matched))); err != nil {
+		return exitWithError(err, meta.LoadError)
+	} else if !ok {
+		fmt.Fprintln(out, "已取消")
+		return nil
+	}
+
+	for _, model := range matched {
+		item := removeModelOutput{Success: true, ModelId: model.Id, ModelName: model.Name, ModelType: model.Type}
+		result := actions.DeleteModel(c.Context, apiKey, args.BaseDomain, model.Id)
+		if result.Success {
+			output.Removed++
+			fmt.Fprintf(out, "✓ 已删除 %s（%s, id=%d）\n", model.Name, model.Type, model.Id)
+		} else {
+			output.Failed++
+			output.Success = false
+			item.Success = false
+			item.Error = newErrorOutput(result.Error)
+			fmt.Fprintf(os.Stderr, "✗ 删除 %s（%s, id=%d）失败: %v\n", model.Name, model.Type, model.Id, result.Error)
+		}
+		output.Models = append(output.Models, item)
+	}
+	fmt.Fprintf(out, "\n删除完成：成功 %d 个，失败 %d 个\n", output.Removed, output.Failed)
+
+	if structuredOutput() {
+		if err := writeOutput(output); err != nil {
+			return cli.Exit(err, meta.LoadError)
+		}
+	}
+	if output.Failed > 0 {
+		return cli.Exit(fmt.Sprintf("%d 个模型删除失败", output.Failed), meta.ServerError)
+	}
+	return nil
+}
+
+// checkModelType 指定了 --type 时，要删除的模型必须是该类型
+func checkModelType(detail *lib.BizyModelDetail, modelType string) error {
+	if modelType != "" && detail.Type != modelType {
+		return lib.WithStep("查找模型", lib.NewValidationError(
+			fmt.Sprintf("模型 '%s'（id=%d）的类型为 %s，与 --type %s 不一致", detail.Name, detail.Id, detail.Type, modelType)))
+	}
+	return nil
+}
+
+// confirmRemoval 删除前确认：--yes 直接通过，非交互模式下要求 --yes
+func confirmRemoval(args *config.Argument, question string) (bool, error) {
+	if args.Yes {
+		return true, nil
+	}
+	if !interactive() {
+		return false, lib.NewValidationError("非交互模式下请使用 --yes 确认删除，或使用 --dry-run 预览")
+	}
+	return confirm(question, false), nil
+}
+
+// finishDryRun 输出预览结果，不执行删除
+func finishDryRun(output interface{}) error {
+	if structuredOutput() {
+		return writeOutput(output)
+	}
+	fmt.Fprintln(msgOut(), "（dry-run）未执行删除")
+	return nil
+}
+
+func newRemoveTargetFromDetail(detail *lib.BizyModelDetail) removeTarget {
+	target := removeTarget{Id: detail.Id, Name: detail.Name, Type: detail.Type, Counter: detail.Counter}
+	for _, ver := range detail.Versions {
+		target.Versions = append(target.Versions, removeTargetVersion{
+			Version: ver.Version, FileName: ver.FileName, FileSize: ver.FileSize, Counter: ver.Counter,
+		})
+	}
+	return target
+}
+
+func newRemoveTargetFromInfo(model *lib.BizyModelInfo) removeTarget {
+	target := removeTarget{Id: model.Id, Name: model.Name, Type: model.Type, Counter: model.Counter}
+	for _, ver := range model.Versions {
+		target.Versions = append(target.Versions, removeTargetVersion{
+			Version: ver.Version, FileName: ver.FileName, FileSize: ver.FileSize, Counter: ver.Counter,
+		})
+	}
+	return target
+}
+
+// printRemoveTarget 输出待删除模型及其版本、统计信息
+func printRemoveTarget(w io.Writer, target removeTarget) {
+	fmt.Fprintf(w, "  %s（%s, id=%d）%d 个版本，%s\n", target.Name, target.Type, target.Id, len(target.Versions), formatCounter(target.Counter))
+	for _, ver := range target.Versions {
+		fmt.Fprintf(w, "    - %s  %s  %s  %s\n", ver.Version, dash(ver.FileName), format.FormatBytes(ver.FileSize), formatCounter(ver.Counter))
+	}
+}
+
+// removeModelVersion 删除模型的单个版本，删除前展示版本信息并要求确认
+func removeModelVersion(c *cli.Context, args *config.Argument, version string) error {
+	apiKey, err := resolveApiKey(args)
+	if err != nil {
+		return exitWithError(err, meta.LoadError)
+	}
+
+	modelId, err := resolveModelTarget(c, args, apiKey)
+	if err != nil {
+		return exitWithError(err, meta.LoadError)
+	}
+	detailResult := actions.GetModelDetail(c.Context, apiKey, args.BaseDomain, modelId)
+	if detailResult.Error != nil {
+		return exitWithError(detailResult.Error, meta.ServerError)
+	}
+	detail := detailResult.Detail
+	if err := checkModelType(detail, args.Type); err != nil {
+		return exitWithError(err, meta.LoadError)
+	}
+	ver, err := actions.FindDetailVersion(*detail, version)
+	if err != nil {
+		return exitWithError(lib.WithStep("查找版本", err), meta.LoadError)
+	}
+	if len(detail.Versions) == 1 {
+		return exitWithError(lib.WithStep("删除版本", lib.NewValidationError(
+			fmt.Sprintf("版本 %s 是模型 '%s' 的唯一版本，如需删除请去掉 --version 删除整个模型", ver.Version, detail.Name))), meta.LoadError)
+	}
+
+	output := removeModelOutput{Success: true, DryRun: args.DryRun, ModelId: detail.Id, ModelName: detail.Name, ModelType: detail.Type, Version: ver.Version, Warning: removeVersionWarning}
+
+	out := msgOut()
+	fmt.Fprintf(out, "将删除模型 '%s'（%s, id=%d）的版本 %s：\n", detail.Name, detail.Type, detail.Id, ver.Version)
+	fmt.Fprintf(out, "  文件名:    %s\n", dash(ver.FileName))
+	fmt.Fprintf(out, "  文件大小:  %s\n", format.FormatBytes(ver.FileSize))
+	fmt.Fprintf(out, "  统计:      %s\n", formatCounter(ver.Counter))
+	fmt.Fprintf(out, "注意：%s\n", removeVersionWarning)
+	if args.DryRun {
+		return finishDryRun(output)
+	}
+	if ok, err := confirmRemoval(args, "确认删除该版本？"); err != nil {
+		return exitWithError(err, meta.LoadError)
+	} else if !ok {
+		fmt.Fprintln(out, "已取消")
+		return nil
+	}
+
+	result := actions.DeleteModelVersions(c.Context, apiKey, args.BaseDomain, detail.Id, []string{ver.Version})
 	if !result.Success {
-		return cli.Exit(result.Error, meta.ServerError)
+		return exitWithError(result.Error, meta.ServerError)
 	}
+	output.NewModelId = result.ModelId
 
-	fmt.Fprintln(os.Stdout, "Model removed successfully.")
+	if structuredOutput() {
+		return writeOutput(output)
+	}
+	fmt.Fprintf(os.Stdout, "版本 %s 已删除\n", ver.Version)
+	printNewModelId(os.Stdout, detail.Id, result.ModelId)
 	return nil
 }
+
+// printNewModelId 提示删除版本后重新提交得到的模型 ID
+func printNewModelId(w io.Writer, oldId, newId int64) {
+	if newId == 0 {
+		fmt.Fprintf(w, "模型已重新提交，原模型 ID %d 已失效，使用统计随之变化\n", oldId)
+	} else if newId != oldId {
+		fmt.Fprintf(w, "模型已重新提交，模型 ID 由 %d 变为 %d，使用统计随之变化\n", oldId, newId)
+	}
+}
@@ -1,15 +1,17 @@
 package tui
 
 import (
+	"context"
+
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/siliconflow/bizyair-cli/lib/actions"
 )
 
 // 登录校验 + 保存
-func loginCmd(apiKey string) tea.Cmd {
+func loginCmd(ctx context.Context, apiKey string) tea.Cmd {
 	return func() tea.Msg {
 		// 调用统一的登录业务逻辑
-		result := actions.ExecuteLogin(apiKey)
+		result := actions.ExecuteLogin(ctx, apiKey)
 		if !result.Success {
 			return loginDoneMsg{ok: false, err: result.Error}
 		}
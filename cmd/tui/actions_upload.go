@@ -13,19 +13,19 @@ import (
 )
 
 // checkModelExists 检查模型名是否已存在
-func checkModelExists(apiKey, modelName, modelType string) tea.Cmd {
+func checkModelExists(ctx context.Context, apiKey, modelName, modelType string) tea.Cmd {
 	return func() tea.Msg {
 		client := lib.NewClient(meta.DefaultDomain, apiKey)
-		exists, err := client.CheckModelExists(modelName, modelType)
+		exists, err := client.CheckModelExists(ctx, modelName, modelType)
 		return checkModelExistsDoneMsg{exists: exists, err: err}
 	}
 }
 
 // loadBaseModelTypes 从后端加载基础模型类型列表
-func loadBaseModelTypes(apiKey string) tea.Cmd {
+func loadBaseModelTypes(ctx context.Context, apiKey string) tea.Cmd {
 	return func() tea.Msg {
 		client := lib.NewClient(meta.DefaultDomain, apiKey)
-		resp, err := client.GetBaseModelTypes()
+		resp, err := client.GetBaseModelTypes(ctx)
 		if err != nil {
 			return baseModelTypesLoadedMsg{items: nil, err: err}
 		}
@@ -34,10 +34,10 @@ func loadBaseModelTypes(apiKey string) tea.Cmd {
 }
 
 // 多版本上传
-func runUploadActionMulti(u uploadInputs, versions []versionItem) tea.Cmd {
+func runUploadActionMulti(parent context.Context, u uploadInputs, versions []versionItem, limiter *lib.RateLimiter) tea.Cmd {
 	return func() tea.Msg {
 		ch := make(chan tea.Msg, 64)
-		ctx, cancel := context.WithCancel(context.Background())
+		ctx, cancel := context.WithCancel(parent)
 
 		go func() {
 			defer close(ch)
@@ -67,13 +67,14 @@ func runUploadActionMulti(u uploadInputs, versions []versionItem) tea.Cmd {
 
 			// 准备上传输入参数
 			input := actions.UploadInput{
-				ApiKey:     apiKey,
-				BaseDomain: meta.DefaultDomain,
-				ModelType:  u.typ,
-				ModelName:  u.name,
-				Versions:   actionVersions,
-				Overwrite:  false,
-				Context:    ctx, // 传递可取消的context
+				ApiKey:      apiKey,
+				BaseDomain:  meta.DefaultDomain,
+				ModelType:   u.typ,
+				ModelName:   u.name,
+				Versions:    actionVersions,
+				Overwrite:   false,
+				Context:     ctx, // 传递可取消的context
+				RateLimiter: limiter,
 			}
 
 			// 创建TUI回调
@@ -104,6 +105,10 @@ func runUploadActionMulti(u uploadInputs, versions []versionItem) tea.Cmd {
 				for _, err := range result.Errors {
 					sb.WriteString(fmt.Sprintf("- %v\n", err))
 				}
+				if result.PendingCommit {
+					sb.WriteString(fmt.Sprintf("文件已全部上传，提交信息已保存。可运行 `bizyair %s -n '%s' -t %s` 重新提交，无需重新上传\n",
+						meta.CmdCommit, u.name, u.typ))
+				}
 				ch <- actionDoneMsg{
 					out: sb.String(),
 					err: lib.WithStep("上传", fmt.Errorf("上传失败")),
@@ -141,6 +146,7 @@ func (t *tuiUploadCallback) OnProgress(progress actions.UploadProgress) {
 		consumed:  progress.Consumed,
 		total:     progress.Total,
 		verIdx:    progress.VersionIndex,
+		hashing:   progress.Stage == actions.StageHashing,
 	}:
 	default:
 	}
@@ -166,9 +172,9 @@ func (t *tuiUploadCallback) OnCoverStatus(index, total int, status, message stri
 }
 
 // checkVPN 执行VPN检测
-func checkVPN() tea.Cmd {
+func checkVPN(parent context.Context) tea.Cmd {
 	return func() tea.Msg {
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		ctx, cancel := context.WithTimeout(parent, 10*time.Second)
 		defer cancel()
 
 		result := lib.DetectVPN(ctx)
@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"sort"
@@ -16,6 +17,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/siliconflow/bizyair-cli/cmd/tui/filepicker"
 	"github.com/siliconflow/bizyair-cli/lib"
+	"github.com/siliconflow/bizyair-cli/lib/format"
 	"github.com/siliconflow/bizyair-cli/meta"
 	"github.com/urfave/cli/v2"
 )
@@ -103,6 +105,12 @@ type mainModel struct {
 	// VPN检测相关
 	vpnCheckResult *lib.VPNDetectionResult
 	checkingVPN    bool
+
+	// 上传限速器（--limit-rate），nil 表示不限速
+	rateLimiter *lib.RateLimiter
+
+	// 命令的 context，TUI 中发起的请求均由它派生
+	ctx context.Context
 }
 
 func newMainModel() mainModel {
@@ -371,7 +379,7 @@ func (m mainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					return m, nil
 				}
 				m.running = true
-				return m, loginCmd(api)
+				return m, loginCmd(m.ctx, api)
 			case mainStepMenu:
 				if it, ok := m.menu.SelectedItem().(menuEntry); ok {
 					m.currentAction = it.key
@@ -390,12 +398,12 @@ func (m mainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						var cmds []tea.Cmd
 
 						// 启动VPN检测
-						cmds = append(cmds, checkVPN())
+						cmds = append(cmds, checkVPN(m.ctx))
 
 						// 如果还没有加载基础模型类型，则开始加载
 						if len(m.baseModelTypes) == 0 && !m.loadingBaseModelTypes {
 							m.loadingBaseModelTypes = true
-							cmds = append(cmds, loadBaseModelTypes(m.apiKey))
+							cmds = append(cmds, loadBaseModelTypes(m.ctx, m.apiKey))
 						}
 
 						return m, tea.Batch(cmds...)
@@ -524,8 +532,8 @@ func (m mainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				// 多版本上传速率计算
 				if !m.verLastTime[msg.verIdx].IsZero() {
 					duration := now.Sub(m.verLastTime[msg.verIdx]).Seconds()
-					if duration > 0 {
-						bytesDiff := msg.consumed - m.verLastBytes[msg.verIdx]
+					bytesDiff := msg.consumed - m.verLastBytes[msg.verIdx]
+					if duration > 0 && bytesDiff >= 0 {
 						m.verSpeed[msg.verIdx] = int64(float64(bytesDiff) / duration)
 					}
 				}
@@ -538,8 +546,8 @@ func (m mainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				// 单版本上传速率计算
 				if !m.lastUploadTime.IsZero() {
 					duration := now.Sub(m.lastUploadTime).Seconds()
-					if duration > 0 {
-						bytesDiff := msg.consumed - m.lastUploadBytes
+					bytesDiff := msg.consumed - m.lastUploadBytes
+					if duration > 0 && bytesDiff >= 0 {
 						m.currentUploadSpeed = int64(float64(bytesDiff) / duration)
 					}
 				}
@@ -775,7 +783,19 @@ func openMyModelsInBrowser() tea.Cmd {
 
 // 入口
 func MainTUI(c *cli.Context) error {
-	p := tea.NewProgram(newMainModel(), tea.WithAltScreen())
+	m := newMainModel()
+	m.ctx = c.Context
+
+	// --limit-rate / BIZYAIR_LIMIT_RATE 对 TUI 中的上传同样生效
+	if limitRate := c.String("limit-rate"); limitRate != "" {
+		bytesPerSecond, err := format.ParseRate(limitRate)
+		if err != nil {
+			return cli.Exit(fmt.Errorf("limit-rate 参数无效: %w", err), meta.LoadError)
+		}
+		m.rateLimiter = lib.NewRateLimiter(bytesPerSecond)
+	}
+
+	p := tea.NewProgram(m, tea.WithAltScreen())
 	model, err := p.Run()
 	if err != nil {
 		return err
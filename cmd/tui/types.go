@@ -65,6 +65,7 @@ type uploadProgMsg struct {
 	consumed  int64
 	total     int64
 	verIdx    int
+	hashing   bool // 是否处于计算哈希阶段
 }
 
 type uploadCancelMsg struct{}
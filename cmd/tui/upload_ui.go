@@ -221,7 +221,7 @@ func (m *mainModel) renderUploadRunningView() string {
 				m.coverStatus = ""
 				m.coverStatusWarning = false
 			}
-			progressSection.WriteString(fmt.Sprintf("当前: (%s) %s\n", m.uploadProg.fileIndex, m.uploadProg.fileName))
+			progressSection.WriteString(fmt.Sprintf("当前: (%s) %s%s\n", m.uploadProg.fileIndex, hashingLabel(m.uploadProg.hashing), m.uploadProg.fileName))
 		} else {
 			// 准备阶段，显示封面状态或默认提示
 			if m.coverStatus != "" {
@@ -276,7 +276,7 @@ func (m *mainModel) renderUploadRunningView() string {
 				m.coverStatusWarning = false
 			}
 			percent := float64(m.uploadProg.consumed) / float64(m.uploadProg.total)
-			fileLine = fmt.Sprintf("(%s) %s", m.uploadProg.fileIndex, m.uploadProg.fileName)
+			fileLine = fmt.Sprintf("(%s) %s%s", m.uploadProg.fileIndex, hashingLabel(m.uploadProg.hashing), m.uploadProg.fileName)
 			progLine = m.progress.View()
 			speedLine = fmt.Sprintf("%.1f%% (%s/%s) %s/s", percent*100, format.FormatBytes(m.uploadProg.consumed), format.FormatBytes(m.uploadProg.total), format.FormatBytes(m.currentUploadSpeed))
 		} else {
@@ -366,7 +366,7 @@ func (m *mainModel) updateUploadInputs(msg tea.Msg) tea.Cmd {
 				m.act.u.name = name
 				// 调用后端校验模型名是否重复
 				m.running = true
-				return checkModelExists(m.apiKey, name, m.act.u.typ)
+				return checkModelExists(m.ctx, m.apiKey, name, m.act.u.typ)
 			case "esc":
 				m.upStep = stepType
 				return nil
@@ -1102,7 +1102,7 @@ func (m *mainModel) updateUploadInputs(msg tea.Msg) tea.Cmd {
 			switch km.String() {
 			case "enter":
 				m.running = true
-				return runUploadActionMulti(m.act.u, m.act.versions)
+				return runUploadActionMulti(m.ctx, m.act.u, m.act.versions, m.rateLimiter)
 			case "esc":
 				m.act.confirming = false
 				m.upStep = stepAskMore
@@ -1342,3 +1342,11 @@ func (m *mainModel) renderUploadStepsView() string {
 	}
 	return ""
 }
+
+// hashingLabel 计算哈希阶段在文件名前显示的提示
+func hashingLabel(hashing bool) string {
+	if hashing {
+		return "计算哈希 "
+	}
+	return ""
+}
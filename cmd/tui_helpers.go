@@ -5,6 +5,11 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+
+	"github.com/siliconflow/bizyair-cli/config"
+	"github.com/siliconflow/bizyair-cli/lib"
+	"github.com/siliconflow/bizyair-cli/lib/actions"
+	"github.com/urfave/cli/v2"
 )
 
 // 列表项（与 bubbles/list 兼容）
@@ -54,3 +59,42 @@ func absPath(p string) string {
 	wd, _ := os.Getwd()
 	return filepath.Join(wd, p)
 }
+
+// resolveApiKey 优先使用参数中的 API Key，否则读取本地保存的 API Key
+func resolveApiKey(args *config.Argument) (string, error) {
+	if args.ApiKey != "" {
+		return args.ApiKey, nil
+	}
+	return lib.NewSfFolder().GetKey()
+}
+
+// modelTarget 获取命令的目标模型（优先使用位置参数，其次使用 --name）
+func modelTarget(c *cli.Context, args *config.Argument) string {
+	if c.Args().Present() {
+		return c.Args().First()
+	}
+	return args.Name
+}
+
+// resolveModelTarget 解析命令的目标模型 ID：指定 --id 时直接使用，否则按位置参数或 --name 查找
+func resolveModelTarget(c *cli.Context, args *config.Argument, apiKey string) (int64, error) {
+	if args.ModelId != 0 {
+		if args.ModelId < 0 {
+			return 0, lib.NewValidationError(fmt.Sprintf("模型 ID 无效: %d", args.ModelId))
+		}
+		if c.Args().Present() || args.Name != "" {
+			return 0, lib.NewValidationError("--id 不能与模型名称同时指定")
+		}
+		return args.ModelId, nil
+	}
+	return actions.ResolveModelId(c.Context, apiKey, args.BaseDomain, modelTarget(c, args), args.Type)
+}
+
+// checkTrailingArgs 位置参数之后的选项不会被解析，出现多余参数时提示调整顺序
+func checkTrailingArgs(c *cli.Context) error {
+	if c.Args().Len() > 1 {
+		return fmt.Errorf("无法识别的参数 %v，选项需写在模型 ID 或名称之前，如: %s --version v2.0 <id|name>",
+			c.Args().Tail(), c.Command.HelpName)
+	}
+	return nil
+}
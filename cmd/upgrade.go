@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"context"
 	"fmt"
 	"os"
 
@@ -16,23 +15,24 @@ func Upgrade(c *cli.Context) error {
 
 	checkOnly := c.Bool("check")
 	force := c.Bool("force")
+	out := msgOut()
 
-	fmt.Printf("BizyAir CLI 升级工具\n")
-	fmt.Printf("当前版本: %s\n", meta.Version)
-	fmt.Printf("==================\n")
+	fmt.Fprintf(out, "BizyAir CLI 升级工具\n")
+	fmt.Fprintf(out, "当前版本: %s\n", meta.Version)
+	fmt.Fprintf(out, "==================\n")
 
 	// 创建升级选项
 	opts := lib.UpgradeOptions{
 		CheckOnly:      checkOnly,
 		Force:          force,
 		CurrentVersion: meta.Version,
-		Context:        context.Background(),
+		Context:        c.Context,
 		StatusFunc: func(status string) {
-			fmt.Printf("%s\n", status)
+			fmt.Fprintf(out, "%s\n", status)
 		},
 		ProgressFunc: func(downloaded, total int64) {
 			percentage := float64(downloaded) / float64(total) * 100
-			fmt.Printf("\r下载进度: %.1f%% (%s / %s)",
+			fmt.Fprintf(out, "\r下载进度: %.1f%% (%s / %s)",
 				percentage,
 				formatBytes(downloaded),
 				formatBytes(total))
@@ -44,10 +44,20 @@ func Upgrade(c *cli.Context) error {
 
 	// 清除进度行
 	if !checkOnly {
-		fmt.Println()
+		fmt.Fprintln(out)
 	}
 
-	fmt.Printf("==================\n")
+	fmt.Fprintf(out, "==================\n")
+
+	if structuredOutput() {
+		if err := writeOutput(newUpgradeOutput(result)); err != nil {
+			return cli.Exit(err, meta.LoadError)
+		}
+		if !result.Success {
+			return cli.Exit("", meta.LoadError)
+		}
+		return nil
+	}
 
 	if !result.Success {
 		fmt.Fprintf(os.Stderr, "❌ %s\n", result.Message)
@@ -66,6 +76,27 @@ func Upgrade(c *cli.Context) error {
 	return nil
 }
 
+// upgradeOutput upgrade 的结构化输出
+type upgradeOutput struct {
+	Success        bool         `json:"success"`
+	NeedUpgrade    bool         `json:"need_upgrade"`
+	CurrentVersion string       `json:"current_version,omitempty"`
+	LatestVersion  string       `json:"latest_version,omitempty"`
+	Message        string       `json:"message,omitempty"`
+	Error          *errorOutput `json:"error,omitempty"`
+}
+
+func newUpgradeOutput(result *lib.UpgradeResult) upgradeOutput {
+	return upgradeOutput{
+		Success:        result.Success,
+		NeedUpgrade:    result.NeedUpgrade,
+		CurrentVersion: result.CurrentVersion,
+		LatestVersion:  result.LatestVersion,
+		Message:        result.Message,
+		Error:          newErrorOutput(result.Error),
+	}
+}
+
 // formatBytes 格式化字节数
 func formatBytes(bytes int64) string {
 	const unit = 1024
@@ -1,12 +1,18 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/charmbracelet/lipgloss"
 	"github.com/cloudwego/hertz/cmd/hz/util/logs"
+	"github.com/siliconflow/bizyair-cli/config"
 	"github.com/siliconflow/bizyair-cli/lib"
 	"github.com/siliconflow/bizyair-cli/lib/actions"
 	"github.com/siliconflow/bizyair-cli/lib/format"
@@ -25,21 +31,31 @@ func Upload(c *cli.Context) error {
 	// 检查是否使用 YAML 配置文件批量上传
 	if args.FilePath != "" {
 		// 使用 YAML 配置文件
-		if err := uploadFromYaml(args.FilePath, args); err != nil {
-			return cli.Exit(err, meta.LoadError)
+		if err := uploadFromYaml(c, args.FilePath, args); err != nil {
+			// 已写出结果并指定了退出码（如取消）时直接返回，避免再输出一份错误文档
+			var exit cli.ExitCoder
+			if errors.As(err, &exit) {
+				return err
+			}
+			return exitWithError(err, meta.LoadError)
 		}
 		return nil
 	}
 
 	// 获取 API Key
-	apiKey := args.ApiKey
-	if apiKey == "" {
-		apiKey, err = lib.NewSfFolder().GetKey()
-		if err != nil {
-			return cli.Exit(err, meta.LoadError)
-		}
+	apiKey, err := resolveApiKey(args)
+	if err != nil {
+		return exitWithError(err, meta.LoadError)
 	}
 
+	// 未指定文件时 --resume-commit 直接使用待提交记录提交
+	if args.ResumeCommit && len(args.Path) == 0 {
+		result := commitPending(c, args, apiKey, args.Name, args.Type)
+		return handleUploadResult(args, apiKey, result)
+	}
+
+	out := msgOut()
+
 	// 准备版本输入参数
 	versions := make([]actions.VersionInput, len(args.Path))
 	for i := range args.Path {
@@ -49,14 +65,14 @@ func Upload(c *cli.Context) error {
 		if introPath != "" {
 			// 从文件读取
 			if err := lib.ValidateIntroFile(introPath); err != nil {
-				return cli.Exit(fmt.Errorf("intro 文件验证失败 [版本 %d]: %w", i+1, err), meta.LoadError)
+				return exitWithError(fmt.Errorf("intro 文件验证失败 [版本 %d]: %w", i+1, err), meta.LoadError)
 			}
 			content, err := lib.ReadIntroFile(introPath)
 			if err != nil {
-				return cli.Exit(fmt.Errorf("读取 intro 文件失败 [版本 %d]: %w", i+1, err), meta.LoadError)
+				return exitWithError(fmt.Errorf("读取 intro 文件失败 [版本 %d]: %w", i+1, err), meta.LoadError)
 			}
 			intro = content
-			fmt.Fprintf(os.Stdout, "已从文件读取 intro (版本 %d/%d): %s\n", i+1, len(args.Path), introPath)
+			fmt.Fprintf(out, "已从文件读取 intro (版本 %d/%d): %s\n", i+1, len(args.Path), introPath)
 		} else {
 			// 使用直接提供的 intro
 			intro = getStringAt(args.Intro, i, "")
@@ -64,11 +80,16 @@ func Upload(c *cli.Context) error {
 
 		// 验证 intro 不能为空
 		if strings.TrimSpace(intro) == "" {
-			return cli.Exit(fmt.Errorf("模型介绍（intro）是必填项，请提供介绍文本或通过 intro_path 指定介绍文件 [版本 %d]", i+1), meta.LoadError)
+			return exitWithError(fmt.Errorf("模型介绍（intro）是必填项，请提供介绍文本或通过 intro_path 指定介绍文件 [版本 %d]", i+1), meta.LoadError)
 		}
 
+		// 追加版本时未指定的版本号留空，由远端已有版本决定
+		defaultVersion := fmt.Sprintf("v%d.0", i+1)
+		if args.Append {
+			defaultVersion = ""
+		}
 		versions[i] = actions.VersionInput{
-			Version:      getVersionAt(args.ModelVersion, i, fmt.Sprintf("v%d.0", i+1)),
+			Version:      getVersionAt(args.ModelVersion, i, defaultVersion),
 			Path:         args.Path[i],
 			BaseModel:    getStringAt(args.BaseModel, i, ""),
 			Introduction: intro,
@@ -77,59 +98,146 @@ func Upload(c *cli.Context) error {
 		}
 	}
 
+	// 上次文件已上传但提交失败、且与本次版本一致时，可跳过上传直接提交
+	if offerResumeCommit(c.Context, args, args.Name, args.Type, versions) {
+		result := commitPending(c, args, apiKey, args.Name, args.Type)
+		return handleUploadResult(args, apiKey, result)
+	}
+
+	opts, err := resolveUploadOptions(args, nil)
+	if err != nil {
+		return exitWithError(err, meta.LoadError)
+	}
+	defer opts.close()
+
 	// 准备上传输入参数
 	input := actions.UploadInput{
-		ApiKey:     apiKey,
-		BaseDomain: args.BaseDomain,
-		ModelType:  args.Type,
-		ModelName:  args.Name,
-		Versions:   versions,
-		Overwrite:  args.Overwrite,
+		ApiKey:      apiKey,
+		BaseDomain:  args.BaseDomain,
+		ModelType:   args.Type,
+		ModelName:   args.Name,
+		Versions:    versions,
+		Overwrite:   args.Overwrite,
+		Append:      args.Append,
+		NoHashCache: args.NoHashCache,
+		Context:     c.Context,
 	}
+	opts.apply(&input)
 
 	// 创建CLI回调
-	callback := &cliUploadCallback{}
+	callback := newCliUploadCallback("")
 
 	// 执行上传
-	fmt.Fprintf(os.Stdout, "开始上传 %d 个文件（并发数：3）\n", len(versions))
+	printFolderTrees(versions)
+	fmt.Fprintf(out, "开始上传 %d 个文件（并发数：%d）\n", len(versions), opts.Scheduler.Workers())
 	result := actions.ExecuteUpload(input, callback)
+	return handleUploadResult(args, apiKey, result)
+}
+
+// handleUploadResult 输出上传（或直接提交）的结果并决定退出码
+func handleUploadResult(args *config.Argument, apiKey string, result actions.UploadResult) error {
+	out := msgOut()
+	output := newUploadOutput(args.Name, args.Type, result)
 
 	// 处理结果
 	if !result.Success {
 		if result.CanceledByUser {
-			fmt.Fprintln(os.Stdout, "\n上传已取消")
+			fmt.Fprintln(out, "\n上传已取消")
 			folder, _ := lib.GetCheckpointDir()
 			if folder != "" {
-				fmt.Fprintf(os.Stdout, "已上传的部分已保存checkpoint，下次上传相同文件时会自动续传。\n")
-				fmt.Fprintf(os.Stdout, "Checkpoint文件位置: %s\n", folder)
+				fmt.Fprintf(out, "已上传的部分已保存checkpoint，下次上传相同文件时会自动续传。\n")
+				fmt.Fprintf(out, "Checkpoint文件位置: %s\n", folder)
+			}
+			if structuredOutput() {
+				if err := writeOutput(output); err != nil {
+					return cli.Exit(err, meta.LoadError)
+				}
+			}
+			return exitCanceled("")
+		}
+
+		if structuredOutput() {
+			if result.PendingCommit {
+				printPendingCommitHint(args.Name, args.Type)
+			}
+			if err := writeOutput(output); err != nil {
+				return cli.Exit(err, meta.LoadError)
 			}
-			return nil
+			return cli.Exit("", meta.ServerError)
 		}
 
 		fmt.Fprintf(os.Stderr, "\n上传失败，错误列表：\n")
 		for _, err := range result.Errors {
 			fmt.Fprintf(os.Stderr, "  - %v\n", err)
 		}
+		if result.PendingCommit {
+			printPendingCommitHint(args.Name, args.Type)
+		}
 		return cli.Exit("上传失败", meta.ServerError)
 	}
 
-	fmt.Fprintf(os.Stdout, "\n✓ 上传成功！\n")
+	fmt.Fprintf(out, "\n✓ 上传成功！\n")
+	if args.Append {
+		names := make([]string, 0, len(result.Versions))
+		for _, ver := range result.Versions {
+			names = append(names, ver.Version)
+		}
+		fmt.Fprintf(out, "已追加版本: %s\n", strings.Join(names, ", "))
+	}
 	if result.SuccessCount < result.TotalCount {
-		fmt.Fprintf(os.Stdout, "部分版本失败：成功 %d/%d\n",
+		fmt.Fprintf(out, "部分版本失败：成功 %d/%d\n",
 			result.SuccessCount, result.TotalCount)
 		for _, err := range result.Errors {
 			fmt.Fprintf(os.Stderr, "  - %v\n", err)
 		}
+		if structuredOutput() {
+			return writeOutput(output)
+		}
 		return nil
 	}
 
 	// 全部成功时，显示模型详情
-	displayUploadedModelDetail(apiKey, args.BaseDomain, result.ModelName, result.ModelType)
+	displayUploadedModelDetail(apiKey, args.BaseDomain, &output)
+	if structuredOutput() {
+		return writeOutput(output)
+	}
 	return nil
 }
 
-// displayUploadedModelDetail 显示刚上传的模型详情
-func displayUploadedModelDetail(apiKey, baseDomain, modelName, modelType string) {
+// uploadOutput 上传结果的结构化输出
+type uploadOutput struct {
+	Success       bool                `json:"success"`
+	Canceled      bool                `json:"canceled,omitempty"`
+	ModelName     string              `json:"model_name"`
+	ModelType     string              `json:"model_type"`
+	ModelId       int64               `json:"model_id,omitempty"`
+	ModelURL      string              `json:"model_url,omitempty"`
+	SuccessCount  int                 `json:"success_count"`
+	TotalCount    int                 `json:"total_count"`
+	Versions      []*lib.ModelVersion `json:"versions,omitempty"`
+	Errors        []*errorOutput      `json:"errors,omitempty"`
+	PendingCommit bool                `json:"pending_commit,omitempty"` // 文件已上传但提交失败，已保存待提交记录
+	Skipped       bool                `json:"skipped,omitempty"`        // 已按锁文件发布且文件未变化，未上传
+}
+
+func newUploadOutput(modelName, modelType string, result actions.UploadResult) uploadOutput {
+	return uploadOutput{
+		Success:       result.Success,
+		Canceled:      result.CanceledByUser,
+		ModelName:     modelName,
+		ModelType:     modelType,
+		SuccessCount:  result.SuccessCount,
+		TotalCount:    result.TotalCount,
+		Versions:      result.Versions,
+		Errors:        newErrorOutputs(result.Errors),
+		PendingCommit: result.PendingCommit,
+	}
+}
+
+// displayUploadedModelDetail 显示刚上传的模型详情，并把模型 ID 与链接回填到 output
+func displayUploadedModelDetail(apiKey, baseDomain string, output *uploadOutput) {
+	out := msgOut()
+
 	// 后端需要时间处理，先等待1秒
 	time.Sleep(time.Second)
 
@@ -137,7 +245,7 @@ func displayUploadedModelDetail(apiKey, baseDomain, modelName, modelType string)
 	listInput := actions.ListModelsInput{
 		ApiKey:     apiKey,
 		BaseDomain: baseDomain,
-		ModelType:  modelType,
+		ModelType:  output.ModelType,
 		Current:    1,
 		PageSize:   100,
 		Sort:       "Recently",
@@ -162,7 +270,7 @@ func displayUploadedModelDetail(apiKey, baseDomain, modelName, modelType string)
 
 		// 查找匹配的模型
 		for _, model := range listResult.Models {
-			if model.Name == modelName {
+			if model.Name == output.ModelName {
 				targetModel = model
 				break
 			}
@@ -185,59 +293,135 @@ func displayUploadedModelDetail(apiKey, baseDomain, modelName, modelType string)
 
 	// 构建模型详情页面 URL
 	modelURL := fmt.Sprintf("https://bizyair.cn/community/models/my/%d", targetModel.Id)
+	output.ModelId = targetModel.Id
+	output.ModelURL = modelURL
 
 	// 显示成功提示和链接
-	fmt.Fprintf(os.Stdout, "\n模型发布成功！\n")
-	fmt.Fprintf(os.Stdout, "模型链接: %s\n", modelURL)
+	fmt.Fprintf(out, "\n模型发布成功！\n")
+	fmt.Fprintf(out, "模型链接: %s\n", modelURL)
+
+	// 结构化输出用于脚本场景，不打开浏览器
+	if structuredOutput() {
+		return
+	}
 
 	// 尝试在浏览器中打开模型详情页面
 	msg, err := lib.OpenBrowser(modelURL)
 	if err != nil {
 		// 如果无法打开浏览器，只是提示，不影响整体流程
-		fmt.Fprintf(os.Stdout, "提示: 无法自动打开浏览器，请手动访问上述链接查看\n")
+		fmt.Fprintf(out, "提示: 无法自动打开浏览器，请手动访问上述链接查看\n")
 	} else {
-		fmt.Fprintf(os.Stdout, "%s\n", msg)
+		fmt.Fprintf(out, "%s\n", msg)
 	}
 }
 
+// printFolderTrees 对目录形式的版本打印将要上传的文件树
+func printFolderTrees(versions []actions.VersionInput) {
+	out := msgOut()
+	for i, ver := range versions {
+		if !lib.IsDir(ver.Path) {
+			continue
+		}
+		files, err := lib.CollectUploadFiles(ver.Path)
+		if err != nil {
+			// 目录错误交由上传前的参数校验统一报告
+			continue
+		}
+		var size int64
+		for _, file := range files {
+			size += file.Size
+		}
+		name := ver.Version
+		if name == "" {
+			name = "自动编号"
+		}
+		fmt.Fprintf(out, "版本 %d (%s) 目录 %s：%d 个文件，共 %s\n",
+			i+1, name, filepath.Base(ver.Path), len(files), format.FormatBytes(size))
+		lib.BuildFileTree(ver.Path, files).FprintTree(out, "  ")
+	}
+}
+
+// progressLine 所有上传回调共用的进度行
+// 批量上传时多个模型同时输出，\r 进度行与普通消息需串行输出，否则会相互穿插
+var progressLine struct {
+	sync.Mutex
+	width int // 当前进度行的显示宽度，0 表示没有未换行的进度行
+}
+
 // cliUploadCallback CLI的进度回调实现
-type cliUploadCallback struct{}
+type cliUploadCallback struct {
+	out    io.Writer // 进度输出目标，结构化输出模式下为 stderr
+	prefix string    // 输出前缀，批量上传时用于区分同时进行的模型
+}
+
+func newCliUploadCallback(prefix string) *cliUploadCallback {
+	return &cliUploadCallback{out: msgOut(), prefix: prefix}
+}
+
+// println 输出一行消息，先结束未换行的进度行
+func (c *cliUploadCallback) println(w io.Writer, format string, a ...interface{}) {
+	progressLine.Lock()
+	defer progressLine.Unlock()
+	if progressLine.width > 0 {
+		fmt.Fprintln(c.out)
+		progressLine.width = 0
+	}
+	fmt.Fprintf(w, format+"\n", a...)
+}
 
 func (c *cliUploadCallback) OnProgress(progress actions.UploadProgress) {
 	if progress.Total > 0 {
 		percent := float64(progress.Consumed) / float64(progress.Total)
 		bar := renderProgressBar(percent)
-		fmt.Printf("\r(%d/%d) %s %s %.1f%% (%s/%s)",
+		stage := ""
+		if progress.Stage == actions.StageHashing {
+			stage = "计算哈希 "
+		}
+		line := fmt.Sprintf("%s(%d/%d) %s%s %s %.1f%% (%s/%s)",
+			c.prefix,
 			progress.VersionIndex+1,
 			progress.VersionTotal,
+			stage,
 			progress.FileName,
 			bar,
 			percent*100,
 			format.FormatBytes(progress.Consumed),
 			format.FormatBytes(progress.Total))
 
+		progressLine.Lock()
+		defer progressLine.Unlock()
+		// 用空格覆盖上一条更长的进度行（可能来自其他模型）
+		width := lipgloss.Width(line)
+		pad := ""
+		if progressLine.width > width {
+			pad = strings.Repeat(" ", progressLine.width-width)
+		}
+		fmt.Fprintf(c.out, "\r%s%s", line, pad)
+		progressLine.width = width
+
 		if percent >= 1.0 {
-			fmt.Println()
+			fmt.Fprintln(c.out)
+			progressLine.width = 0
 		}
 	}
 }
 
 func (c *cliUploadCallback) OnVersionStart(index, total int, fileName string) {
-	fmt.Printf("开始上传 (%d/%d): %s\n", index+1, total, fileName)
+	c.println(c.out, "%s开始上传 (%d/%d): %s", c.prefix, index+1, total, fileName)
 }
 
 func (c *cliUploadCallback) OnVersionComplete(index, total int, fileName string, err error) {
 	if err != nil {
-		fmt.Printf("✗ (%d/%d) %s 上传失败: %v\n", index+1, total, fileName, err)
+		c.println(c.out, "%s✗ (%d/%d) %s 上传失败: %v", c.prefix, index+1, total, fileName, err)
 	} else {
-		fmt.Printf("✓ (%d/%d) %s 上传完成\n", index+1, total, fileName)
+		c.println(c.out, "%s✓ (%d/%d) %s 上传完成", c.prefix, index+1, total, fileName)
 	}
 }
 
 func (c *cliUploadCallback) OnCoverStatus(index, total int, status, message string) {
 	// CLI模式输出警告信息
 	if status == "fallback" {
-		fmt.Fprintf(os.Stderr, "⚠ 警告 - 版本 %d/%d: %s\n", index+1, total, message)
+		c.println(os.Stderr, "%s⚠ 警告 - 版本 %d/%d: %s", c.prefix, index+1, total, message)
 	}
 }
 
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/siliconflow/bizyair-cli/config"
+	"github.com/siliconflow/bizyair-cli/lib"
+	"github.com/siliconflow/bizyair-cli/lib/actions"
+	"github.com/siliconflow/bizyair-cli/lib/format"
+)
+
+// uploadOptions 合并命令行参数、环境变量与 YAML upload 配置后的上传参数
+type uploadOptions struct {
+	Multipart   lib.MultipartConfig
+	RateLimiter *lib.RateLimiter     // 所有模型、版本的上传共享同一个限速器
+	Scheduler   *lib.UploadScheduler // 所有模型、版本的分片共享同一个调度器
+}
+
+// resolveUploadOptions 解析上传参数
+// 命令行参数与环境变量优先，其次为 YAML 中的 upload 配置（可为 nil），最后使用默认值
+func resolveUploadOptions(args *config.Argument, upload *config.YamlUpload) (*uploadOptions, error) {
+	if upload == nil {
+		upload = &config.YamlUpload{}
+	}
+
+	partSize, err := parseSizeOption("part-size", args.PartSize, upload.PartSize)
+	if err != nil {
+		return nil, err
+	}
+	threshold, err := parseSizeOption("multipart-threshold", args.Threshold, upload.MultipartThreshold)
+	if err != nil {
+		return nil, err
+	}
+	parallel := args.Parallel
+	if parallel == 0 {
+		parallel = upload.Parallel
+	}
+	if parallel < 0 {
+		return nil, fmt.Errorf("parallel 参数无效: 并发数不能为负数")
+	}
+
+	multipart := lib.MultipartConfig{
+		PartSize:  partSize,
+		Threshold: threshold,
+	}
+	if err := multipart.Validate(); err != nil {
+		return nil, err
+	}
+
+	limitRate := args.LimitRate
+	if limitRate == "" {
+		limitRate = upload.LimitRate
+	}
+	var limiter *lib.RateLimiter
+	if limitRate != "" {
+		bytesPerSecond, err := format.ParseRate(limitRate)
+		if err != nil {
+			return nil, fmt.Errorf("limit-rate 参数无效: %w", err)
+		}
+		limiter = lib.NewRateLimiter(bytesPerSecond)
+	}
+
+	return &uploadOptions{
+		Multipart:   multipart,
+		RateLimiter: limiter,
+		Scheduler:   lib.NewUploadScheduler(parallel),
+	}, nil
+}
+
+// parseSizeOption 解析大小参数，value 为空时使用 fallback，均为空返回 0（使用默认值）
+func parseSizeOption(name, value, fallback string) (int64, error) {
+	if value == "" {
+		value = fallback
+	}
+	if value == "" {
+		return 0, nil
+	}
+	size, err := format.ParseBytes(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s 参数无效: %w", name, err)
+	}
+	return size, nil
+}
+
+// apply 将上传参数写入上传输入
+func (o *uploadOptions) apply(input *actions.UploadInput) {
+	input.Multipart = o.Multipart
+	input.RateLimiter = o.RateLimiter
+	input.Scheduler = o.Scheduler
+}
+
+// close 释放上传参数持有的资源（停止调度器的 worker）
+func (o *uploadOptions) close() {
+	o.Scheduler.Close()
+}
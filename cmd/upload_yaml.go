@@ -1,14 +1,18 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/siliconflow/bizyair-cli/config"
 	"github.com/siliconflow/bizyair-cli/lib"
 	"github.com/siliconflow/bizyair-cli/lib/actions"
+	"github.com/siliconflow/bizyair-cli/meta"
+	"github.com/urfave/cli/v2"
 )
 
 // modelUploadResult 单个模型的上传结果
@@ -19,72 +23,224 @@ type modelUploadResult struct {
 	Error          error
 	VersionSuccess int
 	VersionTotal   int
+	PendingCommit  bool                // 文件已上传但提交失败，已保存待提交记录
+	Skipped        bool                // 已按锁文件发布且文件未变化，未上传
+	Versions       []*lib.ModelVersion // 本次上传的版本
+	Output         uploadOutput        // 结构化输出
+}
+
+// batchUploadOutput YAML 批量上传的结构化输出
+type batchUploadOutput struct {
+	Success      bool           `json:"success"`
+	Total        int            `json:"total"`
+	SuccessCount int            `json:"success_count"`
+	FailedCount  int            `json:"failed_count"`
+	Models       []uploadOutput `json:"models"`
 }
 
 // uploadFromYaml 从 YAML 配置文件批量上传模型
-func uploadFromYaml(yamlPath string, args *config.Argument) error {
-	// 1. 加载 YAML 配置
-	fmt.Fprintf(os.Stdout, "正在加载配置文件: %s\n", yamlPath)
-	cfg, err := config.LoadYamlConfig(yamlPath)
+func uploadFromYaml(c *cli.Context, yamlPath string, args *config.Argument) error {
+	out := msgOut()
+
+	// 1-3. 加载、规范化并验证 YAML 配置
+	cfg, err := loadYamlConfigFile(yamlPath, args.Vars, config.ValidateYamlConfig)
 	if err != nil {
-		return fmt.Errorf("加载配置文件失败: %w", err)
+		return err
+	}
+	if args.Frozen && args.Append {
+		return fmt.Errorf("--frozen 与 --append 不能同时使用")
+	}
+	if args.Resume && args.Append {
+		return fmt.Errorf("--resume 与 --append 不能同时使用")
+	}
+	// 锁文件记录已发布的版本；追加版本时版本号由远端决定，不使用锁文件
+	var lock *config.LockFile
+	if !args.Append {
+		if lock, err = openLockFile(yamlPath, args.BaseDomain, args.Frozen); err != nil {
+			return err
+		}
 	}
-
-	// 2. 规范化路径（相对路径转为基于 YAML 文件所在目录的路径）
 	yamlDir := filepath.Dir(yamlPath)
-	if err := config.NormalizeModelPaths(cfg, yamlDir); err != nil {
-		return fmt.Errorf("规范化路径失败: %w", err)
+
+	// 4. 获取 API Key
+	apiKey, err := resolveApiKey(args)
+	if err != nil {
+		return fmt.Errorf("未登录或缺少 API Key: %w", err)
 	}
 
-	// 3. 验证配置
-	if err := config.ValidateYamlConfig(cfg); err != nil {
-		return fmt.Errorf("配置验证失败: %w", err)
+	opts, err := resolveUploadOptions(args, cfg.Upload)
+	if err != nil {
+		return fmt.Errorf("上传参数无效: %w", err)
 	}
+	defer opts.close()
 
-	// 4. 获取 API Key
-	apiKey := args.ApiKey
-	if apiKey == "" {
-		apiKey, err = lib.NewSfFolder().GetKey()
-		if err != nil {
-			return fmt.Errorf("未登录或缺少 API Key: %w", err)
-		}
+	// 批量上传进度，中断后可使用 --resume 继续（--frozen 不上传，无需记录）
+	var batch *lib.BatchState
+	resumed := false
+	if !args.Append && !args.Frozen {
+		batch, resumed = openBatchState(args, yamlPath)
 	}
 
 	// 5. 开始批量上传
 	totalModels := len(cfg.Models)
-	fmt.Fprintf(os.Stdout, "\n开始批量上传，共 %d 个模型\n", totalModels)
-	fmt.Fprintln(os.Stdout, strings.Repeat("=", 40))
+	fmt.Fprintf(out, "\n开始批量上传，共 %d 个模型\n", totalModels)
+	fmt.Fprintln(out, strings.Repeat("=", 40))
 
-	// 记录所有模型的上传结果
-	results := make([]modelUploadResult, 0, totalModels)
-
-	// 6. 串行上传每个模型（避免过多并发）
+	// 6. 所有模型同时上传，分片由全局调度器统一排队，总并发数不随模型数增加
+	resume := make([]bool, totalModels)
+	uploads := make([][]config.YamlVersion, totalModels)          // 需要上传的版本，为空时跳过
+	published := make([]bool, totalModels)                        // 模型已在远端创建，本次上传的版本连同远端其余版本一起提交
+	uploaded := make([]map[string]*lib.ModelVersion, totalModels) // 上次已上传文件、可直接提交的版本
+	if batch != nil {
+		published = syncBatchModels(batch, cfg)
+	}
+	var frozenErrs []string
 	for i, model := range cfg.Models {
-		fmt.Fprintf(os.Stdout, "\n[%d/%d] 正在上传模型: %s (%s)\n", i+1, totalModels, model.Name, model.Type)
-		fmt.Fprintf(os.Stdout, "  - 共 %d 个版本\n", len(model.Versions))
-
+		fmt.Fprintf(out, "[%d/%d] %s (%s)，共 %d 个版本\n", i+1, totalModels, model.Name, model.Type, len(model.Versions))
 		// 自动递增版本号
-		versions := config.AutoIncrementVersionNames(model.Versions)
+		versions := yamlVersionNames(args, model.Versions)
+		uploads[i] = versions
 
-		// 转换为 VersionInput 并执行上传
-		result := processModelUpload(apiKey, args.BaseDomain, model.Name, model.Type, versions, args.Overwrite)
-		results = append(results, result)
+		// 跳过锁文件中已发布且文件未变化的版本
+		if lock != nil {
+			lm := lock.Model(model.Name, model.Type)
+			published[i] = published[i] || (lm != nil && lm.ModelId != 0)
+			var pending []config.YamlVersion
+			for _, ver := range versions {
+				ok, reason, err := checkLockedVersion(c.Context, lm, ver.Name, lockRelPath(yamlDir, ver.ModelPath), ver.ModelPath, !args.NoHashCache)
+				if err != nil {
+					return fmt.Errorf("模型 %s 版本 %s: 计算文件签名失败: %w", model.Name, ver.Name, err)
+				}
+				if ok {
+					continue
+				}
+				if args.Frozen {
+					frozenErrs = append(frozenErrs, fmt.Sprintf("%s (%s) 版本 %s: %s", model.Name, model.Type, ver.Name, reason))
+				}
+				pending = append(pending, ver)
+			}
+			uploads[i] = pending
+			if len(pending) == 0 {
+				fmt.Fprintf(out, "  已发布且文件未变化（%s），跳过\n", filepath.Base(lock.Path()))
+				continue
+			}
+			if len(pending) < len(versions) {
+				fmt.Fprintf(out, "  %d 个版本已发布且文件未变化，只上传其余 %d 个版本\n", len(versions)-len(pending), len(pending))
+			}
+		}
+
+		// 从中断处继续：跳过上次已提交的版本，已上传文件的版本直接提交
+		if resumed {
+			pending, reuse, err := resumeBatchVersions(c.Context, batch.Model(model.Name, model.Type), uploads[i], !args.NoHashCache)
+			if err != nil {
+				return fmt.Errorf("模型 %s %w", model.Name, err)
+			}
+			if len(pending) == 0 {
+				fmt.Fprintln(out, "  上次已提交，跳过")
+				uploads[i] = nil
+				continue
+			}
+			if n := len(uploads[i]) - len(pending); n > 0 {
+				fmt.Fprintf(out, "  %d 个版本上次已提交，跳过\n", n)
+			}
+			if len(reuse) > 0 {
+				fmt.Fprintf(out, "  %d 个版本的文件上次已上传，直接提交\n", len(reuse))
+			}
+			uploads[i], uploaded[i] = pending, reuse
+		}
+
+		trees := make([]actions.VersionInput, len(uploads[i]))
+		for j, ver := range uploads[i] {
+			trees[j] = actions.VersionInput{Version: ver.Name, Path: ver.ModelPath}
+		}
+		// 上次文件已上传但提交失败、且与本次要上传的版本一致的模型，可跳过上传直接提交（需在并发上传前询问）
+		// 从中断处继续时，与进度记录一致的待提交记录直接提交
+		if !args.Frozen {
+			resumeArgs := args
+			if resumed && pendingMatchesBatch(args.BaseDomain, batch.Model(model.Name, model.Type)) {
+				resumeArgs = args.Fork()
+				resumeArgs.ResumeCommit = true
+			}
+			if resume[i] = offerResumeCommit(c.Context, resumeArgs, model.Name, model.Type, trees); resume[i] {
+				continue
+			}
+		}
+		// 上传开始后各模型输出交错，目录结构在此提前打印
+		printFolderTrees(trees)
+	}
+	if len(frozenErrs) > 0 {
+		return fmt.Errorf("--frozen: 本地文件与锁文件 %s 不一致:\n  %s", lock.Path(), strings.Join(frozenErrs, "\n  "))
+	}
+	fmt.Fprintf(out, "并发数：%d\n\n", opts.Scheduler.Workers())
+
+	results := make([]modelUploadResult, totalModels)
+	var wg sync.WaitGroup
+	for i, model := range cfg.Models {
+		if !resume[i] && len(uploads[i]) == 0 {
+			results[i] = newSkippedModelResult(model.Name, model.Type, len(model.Versions))
+			if batch != nil {
+				recordBatchSkipped(batch, model.Name, model.Type)
+			}
+			continue
+		}
+		wg.Add(1)
+		go func(i int, model config.YamlModel) {
+			defer wg.Done()
+			if resume[i] {
+				results[i] = newModelUploadResult(model.Name, model.Type, commitPending(c, args, apiKey, model.Name, model.Type))
+				if batch != nil {
+					recordBatchResult(c.Context, batch, results[i], uploads[i])
+				}
+				return
+			}
+			// 模型已发布时更新模型：本次上传的版本连同远端其余版本一起提交
+			var hooks func(input *actions.UploadInput)
+			if batch != nil {
+				hooks = batchUploadHooks(batch, model.Name, model.Type, uploaded[i])
+			}
+			configure := func(input *actions.UploadInput) {
+				if published[i] {
+					input.Update, input.Overwrite = true, false
+				}
+				if hooks != nil {
+					hooks(input)
+				}
+			}
+			// 转换为 VersionInput 并执行上传
+			results[i] = processModelUpload(c.Context, args, opts, apiKey, model.Name, model.Type, uploads[i], configure)
+			if batch != nil {
+				recordBatchResult(c.Context, batch, results[i], uploads[i])
+			}
+		}(i, model)
+	}
+	wg.Wait()
 
-		// 显示结果
-		if result.Success {
-			fmt.Fprintf(os.Stdout, "\n✓ 模型 '%s' 上传成功！(%d/%d 版本成功)\n",
+	// 按配置顺序显示每个模型的结果
+	for _, result := range results {
+		if result.Skipped {
+			fmt.Fprintf(out, "\n✓ 模型 '%s' 已发布且文件未变化，跳过\n", result.ModelName)
+		} else if result.Success {
+			fmt.Fprintf(out, "\n✓ 模型 '%s' 上传成功！(%d/%d 版本成功)\n",
 				result.ModelName, result.VersionSuccess, result.VersionTotal)
 			// 显示模型详情
-			displayUploadedModelDetail(apiKey, args.BaseDomain, result.ModelName, result.ModelType)
+			displayUploadedModelDetail(apiKey, args.BaseDomain, &result.Output)
 		} else {
 			fmt.Fprintf(os.Stderr, "\n✗ 模型 '%s' 上传失败: %v\n", result.ModelName, result.Error)
 		}
 	}
 
-	// 7. 显示汇总结果
+	// 7. 更新锁文件
+	if lock != nil && !args.Frozen {
+		updateLockFromResults(c.Context, lock, apiKey, args.BaseDomain, yamlDir, cfg, results)
+	}
+
+	// 8. 显示汇总结果
 	displayBatchUploadSummary(results)
+	if batch != nil {
+		finishBatchState(batch, yamlPath)
+	}
 
-	// 8. 根据结果决定退出码
+	// 9. 根据结果决定退出码
 	successCount := 0
 	for _, r := range results {
 		if r.Success {
@@ -92,21 +248,77 @@ func uploadFromYaml(yamlPath string, args *config.Argument) error {
 		}
 	}
 
+	if structuredOutput() {
+		output := batchUploadOutput{
+			Success:      successCount == len(results),
+			Total:        len(results),
+			SuccessCount: successCount,
+			FailedCount:  len(results) - successCount,
+			Models:       make([]uploadOutput, 0, len(results)),
+		}
+		for _, r := range results {
+			output.Models = append(output.Models, r.Output)
+		}
+		if err := writeOutput(output); err != nil {
+			return err
+		}
+	}
+
+	if c.Context.Err() != nil {
+		return exitCanceled("上传已取消")
+	}
 	if successCount == 0 {
+		if structuredOutput() {
+			return cli.Exit("", meta.LoadError)
+		}
 		return fmt.Errorf("所有模型上传失败")
 	}
 
 	return nil
 }
 
+// loadYamlConfigFile 加载 YAML 配置（相对路径已转为基于各自文件所在目录的路径），展开通配符并用 validate 验证
+// vars 为命令行 --var key=value
+func loadYamlConfigFile(yamlPath string, vars []string, validate func(*config.YamlConfig) error) (*config.YamlConfig, error) {
+	fmt.Fprintf(msgOut(), "正在加载配置文件: %s\n", yamlPath)
+	overrides, err := config.ParseVars(vars)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := config.LoadYamlConfig(yamlPath, overrides)
+	if err != nil {
+		return nil, fmt.Errorf("加载配置文件失败: %w", err)
+	}
+
+	if err := config.ExpandModelPaths(cfg); err != nil {
+		return nil, fmt.Errorf("展开 model_path 失败: %w", err)
+	}
+
+	if err := validate(cfg); err != nil {
+		return nil, fmt.Errorf("配置验证失败: %w", err)
+	}
+	return cfg, nil
+}
+
+// yamlVersionNames 自动递增版本号
+// 追加版本时保留未指定的版本号，由上传时按远端已有版本编号
+func yamlVersionNames(args *config.Argument, versions []config.YamlVersion) []config.YamlVersion {
+	if args.Append {
+		return versions
+	}
+	return config.AutoIncrementVersionNames(versions)
+}
+
 // processModelUpload 处理单个模型的上传（包括转换和上传）
 func processModelUpload(
+	ctx context.Context,
+	args *config.Argument,
+	opts *uploadOptions,
 	apiKey string,
-	baseDomain string,
 	modelName string,
 	modelType string,
 	versions []config.YamlVersion,
-	overwrite bool,
+	configure func(input *actions.UploadInput),
 ) modelUploadResult {
 	// 转换为 VersionInput
 	versionInputs := make([]actions.VersionInput, len(versions))
@@ -114,12 +326,19 @@ func processModelUpload(
 		// 获取介绍文本
 		intro, err := ver.GetIntroduction()
 		if err != nil {
+			err = fmt.Errorf("读取版本 %d 介绍失败: %w", j+1, err)
 			return modelUploadResult{
 				ModelName:    modelName,
 				ModelType:    modelType,
 				Success:      false,
-				Error:        fmt.Errorf("读取版本 %d 介绍失败: %w", j+1, err),
+				Error:        err,
 				VersionTotal: len(versions),
+				Output: uploadOutput{
+					ModelName:  modelName,
+					ModelType:  modelType,
+					TotalCount: len(versions),
+					Errors:     newErrorOutputs([]error{err}),
+				},
 			}
 		}
 
@@ -134,50 +353,119 @@ func processModelUpload(
 	}
 
 	// 执行上传
-	return uploadSingleModelFromYaml(apiKey, baseDomain, modelName, modelType, versionInputs, overwrite)
+	return uploadSingleModelFromYaml(ctx, args, opts, apiKey, modelName, modelType, versionInputs, configure)
 }
 
 // uploadSingleModelFromYaml 上传单个模型（从 YAML 配置）
 func uploadSingleModelFromYaml(
+	ctx context.Context,
+	args *config.Argument,
+	opts *uploadOptions,
 	apiKey string,
-	baseDomain string,
 	modelName string,
 	modelType string,
 	versions []actions.VersionInput,
-	overwrite bool,
+	configure func(input *actions.UploadInput),
 ) modelUploadResult {
 	// 准备上传输入
 	input := actions.UploadInput{
-		ApiKey:     apiKey,
-		BaseDomain: baseDomain,
-		ModelType:  modelType,
-		ModelName:  modelName,
-		Versions:   versions,
-		Overwrite:  overwrite,
+		ApiKey:      apiKey,
+		BaseDomain:  args.BaseDomain,
+		ModelType:   modelType,
+		ModelName:   modelName,
+		Versions:    versions,
+		Overwrite:   args.Overwrite,
+		Append:      args.Append,
+		NoHashCache: args.NoHashCache,
+		Context:     ctx,
+	}
+	opts.apply(&input)
+	if configure != nil {
+		configure(&input)
 	}
 
-	// 创建回调
-	callback := &cliUploadCallback{}
+	// 创建回调（输出带模型名前缀，区分同时上传的模型）
+	callback := newCliUploadCallback(fmt.Sprintf("[%s] ", modelName))
 
 	// 执行上传
-	fmt.Fprintf(os.Stdout, "开始上传 %d 个文件（并发数：3）\n", len(versions))
 	uploadResult := actions.ExecuteUpload(input, callback)
 
 	// 返回结果
+	return newModelUploadResult(modelName, modelType, uploadResult)
+}
+
+func newModelUploadResult(modelName, modelType string, uploadResult actions.UploadResult) modelUploadResult {
+	err := combineErrors(uploadResult.Errors)
+	if err == nil && uploadResult.CanceledByUser {
+		err = fmt.Errorf("上传已取消: %w", context.Canceled)
+	}
 	return modelUploadResult{
 		ModelName:      modelName,
 		ModelType:      modelType,
 		Success:        uploadResult.Success,
-		Error:          combineErrors(uploadResult.Errors),
+		Error:          err,
 		VersionSuccess: uploadResult.SuccessCount,
 		VersionTotal:   uploadResult.TotalCount,
+		PendingCommit:  uploadResult.PendingCommit,
+		Versions:       uploadResult.Versions,
+		Output:         newUploadOutput(modelName, modelType, uploadResult),
 	}
 }
 
+// newSkippedModelResult 锁文件中已发布且文件未变化的模型
+func newSkippedModelResult(modelName, modelType string, total int) modelUploadResult {
+	return modelUploadResult{
+		ModelName:      modelName,
+		ModelType:      modelType,
+		Success:        true,
+		Skipped:        true,
+		VersionSuccess: total,
+		VersionTotal:   total,
+		Output: uploadOutput{
+			Success:      true,
+			Skipped:      true,
+			ModelName:    modelName,
+			ModelType:    modelType,
+			SuccessCount: total,
+			TotalCount:   total,
+		},
+	}
+}
+
+// updateLockFromResults 将本次上传成功的模型写入锁文件
+func updateLockFromResults(ctx context.Context, lock *config.LockFile, apiKey, baseDomain, yamlDir string, cfg *config.YamlConfig, results []modelUploadResult) {
+	changed := false
+	for i, result := range results {
+		if !result.Success || result.Skipped {
+			continue
+		}
+		detail, err := fetchModelDetail(ctx, apiKey, baseDomain, result.ModelName, result.ModelType)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "⚠ 更新锁文件失败，模型 '%s' 未记录: %v\n", result.ModelName, err)
+			continue
+		}
+		paths := make(map[string]string, len(cfg.Models[i].Versions))
+		for _, ver := range config.AutoIncrementVersionNames(cfg.Models[i].Versions) {
+			paths[ver.Name] = lockRelPath(yamlDir, ver.ModelPath)
+		}
+		refreshLockModel(lock, detail, paths, result.Versions)
+		changed = true
+	}
+	if !changed {
+		return
+	}
+	if err := lock.Save(); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠ %v\n", err)
+		return
+	}
+	fmt.Fprintf(msgOut(), "\n已更新锁文件: %s\n", lock.Path())
+}
+
 // displayBatchUploadSummary 显示批量上传的汇总结果
 func displayBatchUploadSummary(results []modelUploadResult) {
-	fmt.Fprintln(os.Stdout, "\n"+strings.Repeat("=", 40))
-	fmt.Fprintln(os.Stdout, "批量上传完成！")
+	out := msgOut()
+	fmt.Fprintln(out, "\n"+strings.Repeat("=", 40))
+	fmt.Fprintln(out, "批量上传完成！")
 
 	successCount := 0
 	failCount := 0
@@ -189,13 +477,13 @@ func displayBatchUploadSummary(results []modelUploadResult) {
 		}
 	}
 
-	fmt.Fprintf(os.Stdout, "总计: %d 个模型\n", len(results))
-	fmt.Fprintf(os.Stdout, "  ✓ 成功: %d 个\n", successCount)
-	fmt.Fprintf(os.Stdout, "  ✗ 失败: %d 个\n", failCount)
+	fmt.Fprintf(out, "总计: %d 个模型\n", len(results))
+	fmt.Fprintf(out, "  ✓ 成功: %d 个\n", successCount)
+	fmt.Fprintf(out, "  ✗ 失败: %d 个\n", failCount)
 
 	// 如果有失败，显示失败详情
 	if failCount > 0 {
-		fmt.Fprintln(os.Stdout, "\n失败详情：")
+		fmt.Fprintln(out, "\n失败详情：")
 		for _, r := range results {
 			if !r.Success {
 				errorMsg := "未知错误"
@@ -203,6 +491,9 @@ func displayBatchUploadSummary(results []modelUploadResult) {
 					errorMsg = r.Error.Error()
 				}
 				fmt.Fprintf(os.Stderr, "  - %s (%s): %s\n", r.ModelName, r.ModelType, errorMsg)
+				if r.PendingCommit {
+					printPendingCommitHint(r.ModelName, r.ModelType)
+				}
 			}
 		}
 	}
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"time"
+
 	"github.com/urfave/cli/v2"
 )
 
@@ -27,6 +29,31 @@ type Argument struct {
 	IntroPath     []string // 从文件读取 intro
 	Current       int
 	PageSize      int
+	Keyword       string        // 模型列表搜索关键字
+	Sort          string        // 模型列表排序方式
+	Web           bool          // 在浏览器中打开
+	Output        string        // 输出格式：json / yaml，为空时输出文本
+	NoHashCache   bool          // 不使用本地哈希缓存
+	Frozen        bool          // 要求本地文件与锁文件一致，不上传、不更新锁文件
+	Resume        bool          // YAML 批量上传从上次中断处继续
+	PartSize      string        // 分片大小，如 64MB
+	Parallel      int           // 全局并发上传请求数（所有文件的分片共享）
+	Threshold     string        // 分片上传阈值，如 100MB
+	LimitRate     string        // 上传限速，如 20MB/s
+	HTTPTimeout   time.Duration // API 单次请求超时
+	HTTPRetries   int           // API 幂等请求的最大重试次数
+	ResumeCommit  bool          // 跳过上传，使用待提交记录直接提交模型
+	Append        bool          // 向已存在的模型追加版本
+	Yes           bool          // 跳过删除确认
+	DryRun        bool          // 仅预览，不执行删除
+	Glob          string        // 按通配符匹配模型名
+	Regex         string        // 按正则表达式匹配模型名
+	ModelId       int64         // 按模型 ID 指定模型
+	Dir           string        // 下载保存目录
+	WithCovers    bool          // 下载时同时保存封面
+	WithIntro     bool          // 下载时同时保存介绍
+	Prune         bool          // apply 时删除配置中没有的远端版本
+	Vars          []string      // YAML 变量，key=value
 }
 
 func NewArgument() *Argument {
@@ -52,6 +79,7 @@ func (arg *Argument) parseStringSlice(c *cli.Context) {
 	arg.CoverUrls = c.StringSlice("cover")
 	arg.BaseModel = c.StringSlice("base")
 	arg.VersionPublic = c.StringSlice("public")
+	arg.Vars = c.StringSlice("var")
 }
 
 // Fork can copy its own parameters to a new argument
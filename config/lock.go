@@ -0,0 +1,131 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/siliconflow/bizyair-cli/lib"
+	"github.com/siliconflow/bizyair-cli/meta"
+)
+
+// LockFile 记录 YAML 中每个模型发布后的结果（模型/版本 ID、文件签名、封面地址）
+// 与 YAML 放在同一目录，可一并提交到 git，用于跳过已发布的版本和审计
+type LockFile struct {
+	path string
+
+	Version    int          `json:"version"`
+	BaseDomain string       `json:"base_domain"`
+	Models     []*LockModel `json:"models"`
+}
+
+// LockModel 单个模型的发布记录
+type LockModel struct {
+	Name      string         `json:"name"`
+	Type      string         `json:"type"`
+	ModelId   int64          `json:"model_id,omitempty"`
+	Versions  []*LockVersion `json:"versions"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+// LockVersion 单个版本的发布记录
+type LockVersion struct {
+	Version   string           `json:"version"`
+	VersionId int64            `json:"version_id,omitempty"`
+	Path      string           `json:"path"`                 // 相对于 YAML 所在目录，使用 / 分隔
+	Sign      string           `json:"sign,omitempty"`       // 单文件版本的签名
+	Files     []*lib.ModelFile `json:"files,omitempty"`      // 目录版本中每个文件的签名
+	CoverUrls []string         `json:"cover_urls,omitempty"` // 发布后的封面地址
+	CreatedAt string           `json:"created_at,omitempty"` // 远端返回的创建时间
+	UpdatedAt time.Time        `json:"updated_at"`           // 本地记录的发布时间
+}
+
+// LockPath 返回 YAML 对应的锁文件路径
+func LockPath(yamlPath string) string {
+	return strings.TrimSuffix(yamlPath, filepath.Ext(yamlPath)) + meta.LockFileSuffix
+}
+
+// LoadLockFile 读取 YAML 对应的锁文件，不存在时返回空的锁文件
+func LoadLockFile(yamlPath string) (*LockFile, error) {
+	lock := &LockFile{path: LockPath(yamlPath), Version: meta.LockFileVersion}
+	data, err := os.ReadFile(lock.path)
+	if os.IsNotExist(err) {
+		return lock, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("读取锁文件失败: %w", err)
+	}
+	if err := json.Unmarshal(data, lock); err != nil {
+		return nil, fmt.Errorf("解析锁文件 %s 失败: %w", lock.path, err)
+	}
+	if lock.Version > meta.LockFileVersion {
+		return nil, fmt.Errorf("锁文件 %s 的格式版本 %d 过新，请升级 bizyair", lock.path, lock.Version)
+	}
+	lock.Version = meta.LockFileVersion
+	return lock, nil
+}
+
+// Path 返回锁文件路径
+func (l *LockFile) Path() string {
+	return l.path
+}
+
+// Model 查找模型的记录，不存在时返回 nil
+func (l *LockFile) Model(name, modelType string) *LockModel {
+	for _, m := range l.Models {
+		if m.Name == name && m.Type == modelType {
+			return m
+		}
+	}
+	return nil
+}
+
+// SetModel 新增或替换模型的记录
+func (l *LockFile) SetModel(model *LockModel) {
+	for i, m := range l.Models {
+		if m.Name == model.Name && m.Type == model.Type {
+			l.Models[i] = model
+			return
+		}
+	}
+	l.Models = append(l.Models, model)
+}
+
+// Save 按类型、名称排序后写入锁文件，保证多次写入的内容稳定便于 diff
+func (l *LockFile) Save() error {
+	sort.Slice(l.Models, func(i, j int) bool {
+		if l.Models[i].Type != l.Models[j].Type {
+			return l.Models[i].Type < l.Models[j].Type
+		}
+		return l.Models[i].Name < l.Models[j].Name
+	})
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return fmt.Errorf("序列化锁文件失败: %w", err)
+	}
+	data = append(data, '\n')
+
+	tmpFile := l.path + ".tmp"
+	if err := os.WriteFile(tmpFile, data, 0644); err != nil {
+		return fmt.Errorf("写入锁文件失败: %w", err)
+	}
+	if err := os.Rename(tmpFile, l.path); err != nil {
+		_ = os.Remove(tmpFile)
+		return fmt.Errorf("写入锁文件失败: %w", err)
+	}
+	return nil
+}
+
+// Version 查找版本的记录，不存在时返回 nil
+func (m *LockModel) Version(name string) *LockVersion {
+	for _, v := range m.Versions {
+		if v.Version == name {
+			return v
+		}
+	}
+	return nil
+}
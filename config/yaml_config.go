@@ -2,10 +2,10 @@ package config
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"regexp"
-	"strconv"
 	"strings"
 
 	"github.com/siliconflow/bizyair-cli/lib"
@@ -14,70 +14,188 @@ import (
 
 // YamlConfig YAML 配置文件的根结构
 type YamlConfig struct {
-	Models []YamlModel `yaml:"models"`
+	Include  YamlIncludes      `yaml:"include,omitempty"`  // 可选，合并其他 YAML 文件（相对于本文件所在目录，支持通配符）
+	Vars     map[string]string `yaml:"vars,omitempty"`     // 可选，${name} 引用的变量（--var 优先，未定义时使用环境变量）
+	Defaults *YamlDefaults     `yaml:"defaults,omitempty"` // 可选，所有版本继承的默认值
+	Upload   *YamlUpload       `yaml:"upload,omitempty"`   // 可选，上传参数（命令行参数与环境变量优先）
+	Models   []YamlModel       `yaml:"models"`
+}
+
+// YamlDefaults 版本未指定时继承的默认值
+// intro/intro_path、cover_path/cover_url 成对继承：版本指定了其中一个时不再继承另一个
+type YamlDefaults struct {
+	BaseModel string `yaml:"base_model,omitempty"`
+	CoverPath string `yaml:"cover_path,omitempty"`
+	CoverUrl  string `yaml:"cover_url,omitempty"`
+	Intro     string `yaml:"intro,omitempty"`
+	IntroPath string `yaml:"intro_path,omitempty"`
+	Public    *bool  `yaml:"public,omitempty"`
+}
+
+// YamlUpload 上传参数配置
+type YamlUpload struct {
+	PartSize           string `yaml:"part_size"`           // 分片大小，如 64MB
+	Parallel           int    `yaml:"parallel"`            // 全局并发上传请求数（所有文件的分片共享）
+	MultipartThreshold string `yaml:"multipart_threshold"` // 分片上传阈值，如 100MB
+	LimitRate          string `yaml:"limit_rate"`          // 上传总带宽限制，如 20MB/s
 }
 
 // YamlModel 单个模型的配置
 type YamlModel struct {
 	Name     string        `yaml:"name"`
 	Type     string        `yaml:"type"`
+	Defaults *YamlDefaults `yaml:"defaults,omitempty"` // 可选，本模型版本继承的默认值，优先于顶层 defaults
 	Versions []YamlVersion `yaml:"versions"`
+	Source   string        `yaml:"-"` // 定义所在的文件与行号，用于错误提示
 }
 
 // YamlVersion 单个版本的配置
 type YamlVersion struct {
-	Name      string `yaml:"name"`       // 可选，默认递增
-	BaseModel string `yaml:"base_model"` // 基础模型
-	ModelPath string `yaml:"model_path"` // 模型文件路径（必填）
-	CoverPath string `yaml:"cover_path"` // 本地封面文件路径（与 CoverUrl 二选一）
-	CoverUrl  string `yaml:"cover_url"`  // 封面网络 URL（与 CoverPath 二选一）
-	Intro     string `yaml:"intro"`      // 直接文本介绍（与 IntroPath 二选一）
-	IntroPath string `yaml:"intro_path"` // 介绍文件路径（与 Intro 二选一）
-	Public    *bool  `yaml:"public"`     // 是否公开，指针类型以区分未设置和 false
+	Name      string `yaml:"name,omitempty"`       // 可选，默认递增
+	BaseModel string `yaml:"base_model,omitempty"` // 基础模型
+	ModelPath string `yaml:"model_path"`           // 模型文件路径（必填）
+	CoverPath string `yaml:"cover_path,omitempty"` // 本地封面文件路径（与 CoverUrl 二选一）
+	CoverUrl  string `yaml:"cover_url,omitempty"`  // 封面网络 URL（与 CoverPath 二选一）
+	Intro     string `yaml:"intro,omitempty"`      // 直接文本介绍（与 IntroPath 二选一）
+	IntroPath string `yaml:"intro_path,omitempty"` // 介绍文件路径（与 Intro 二选一）
+	Public    *bool  `yaml:"public,omitempty"`     // 是否公开，指针类型以区分未设置和 false
+	NameMatch string `yaml:"name_match,omitempty"` // model_path 含通配符时，从文件名提取版本号的正则（取 version 命名分组或第一个分组）
+	Source    string `yaml:"-"`                    // 定义所在的文件与行号，用于错误提示
 }
 
 // LoadYamlConfig 从文件加载并解析 YAML 配置
-func LoadYamlConfig(filepath string) (*YamlConfig, error) {
-	data, err := os.ReadFile(filepath)
-	if err != nil {
-		return nil, fmt.Errorf("读取 YAML 文件失败: %w", err)
+// 文件可包含多个文档（以 --- 分隔），并通过 include 合并其他文件，所有模型合并为一个配置。
+// 每个文档替换 ${name} 变量（vars 为命令行 --var 指定的值）、将 defaults 填入各版本，
+// 并将相对路径转为基于该文档所在文件目录的绝对路径；include 的文件继承上级文档的 vars 与 defaults。
+func LoadYamlConfig(filepath string, vars map[string]string) (*YamlConfig, error) {
+	loader := &yamlLoader{overrides: vars, loaded: make(map[string]string)}
+	if err := loader.loadFile(filepath, "", yamlScope{}); err != nil {
+		return nil, err
+	}
+	return &loader.config, nil
+}
+
+func (d *YamlDefaults) applyTo(v *YamlVersion) {
+	if d == nil {
+		return
+	}
+	if v.BaseModel == "" {
+		v.BaseModel = d.BaseModel
+	}
+	if v.Public == nil && d.Public != nil {
+		public := *d.Public
+		v.Public = &public
 	}
+	if v.Intro == "" && v.IntroPath == "" {
+		v.Intro, v.IntroPath = d.Intro, d.IntroPath
+	}
+	if v.CoverPath == "" && v.CoverUrl == "" {
+		v.CoverPath, v.CoverUrl = d.CoverPath, d.CoverUrl
+	}
+}
 
-	var config YamlConfig
-	if err := yaml.Unmarshal(data, &config); err != nil {
-		return nil, fmt.Errorf("解析 YAML 文件失败: %w", err)
+// normalized 返回路径转为基于 dir 的绝对路径的副本，供 include 的文件继承
+func (d *YamlDefaults) normalized(dir string) *YamlDefaults {
+	n := *d
+	if n.CoverPath != "" && !filepath.IsAbs(n.CoverPath) && !isURL(n.CoverPath) {
+		n.CoverPath = normalizeRelativePath(dir, n.CoverPath)
 	}
+	if n.IntroPath != "" && !filepath.IsAbs(n.IntroPath) {
+		n.IntroPath = normalizeRelativePath(dir, n.IntroPath)
+	}
+	return &n
+}
 
-	return &config, nil
+// WriteYamlConfig 将配置编码为 YAML 写入 w，可再次通过 LoadYamlConfig 读取
+func WriteYamlConfig(w io.Writer, config *YamlConfig) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(config); err != nil {
+		return fmt.Errorf("生成 YAML 失败: %w", err)
+	}
+	return enc.Close()
+}
+
+// NewYamlModelFromDetail 由线上模型详情生成模型配置
+// model_path 取版本的文件名（相对于 YAML 文件所在目录），可配合 model pull 下载到同一目录
+// cover_url 只能填写一个，版本有多张封面时只取第一张，调用方负责提示
+func NewYamlModelFromDetail(detail lib.BizyModelDetail) YamlModel {
+	model := YamlModel{
+		Name:     detail.Name,
+		Type:     detail.Type,
+		Versions: make([]YamlVersion, 0, len(detail.Versions)),
+	}
+	for _, ver := range detail.Versions {
+		public := ver.Public
+		yamlVer := YamlVersion{
+			Name:      ver.Version,
+			BaseModel: ver.BaseModel,
+			ModelPath: filepath.Base(ver.FileName),
+			Intro:     ver.Intro,
+			Public:    &public,
+		}
+		if ver.FileName == "" {
+			yamlVer.ModelPath = ""
+		}
+		if len(ver.CoverUrls) > 0 {
+			yamlVer.CoverUrl = ver.CoverUrls[0]
+		}
+		model.Versions = append(model.Versions, yamlVer)
+	}
+	return model
 }
 
 // ValidateYamlConfig 验证 YAML 配置的合法性
 func ValidateYamlConfig(config *YamlConfig) error {
+	return validateYamlConfig(config, true)
+}
+
+// ValidateSyncConfig 验证 plan/apply 使用的配置
+// 与 ValidateYamlConfig 相同，但 intro 可省略，省略时沿用远端版本的介绍
+func ValidateSyncConfig(config *YamlConfig) error {
+	return validateYamlConfig(config, false)
+}
+
+func validateYamlConfig(config *YamlConfig, requireIntro bool) error {
 	if len(config.Models) == 0 {
 		return fmt.Errorf("配置文件中至少需要一个模型")
 	}
 
+	defined := make(map[string]string, len(config.Models))
 	for i, model := range config.Models {
+		at := sourcePrefix(model.Source)
 		// 验证模型名称
 		if err := lib.ValidateModelName(model.Name); err != nil {
-			return fmt.Errorf("模型 %d (%s): 名称无效: %w", i+1, model.Name, err)
+			return fmt.Errorf("%s模型 %d (%s): 名称无效: %w", at, i+1, model.Name, err)
 		}
 
 		// 验证模型类型
 		if err := lib.ValidateModelType(model.Type); err != nil {
-			return fmt.Errorf("模型 %d (%s): 类型无效: %w", i+1, model.Name, err)
+			return fmt.Errorf("%s模型 %d (%s): 类型无效: %w", at, i+1, model.Name, err)
 		}
 
+		// 同名同类型的模型只能定义一次（多个文件合并时容易重复）
+		key := model.Type + "/" + model.Name
+		if prev, ok := defined[key]; ok {
+			return fmt.Errorf("%s模型 %s (%s) 重复定义，另见 %s", at, model.Name, model.Type, prev)
+		}
+		defined[key] = model.Source
+
 		// 验证至少有一个版本
 		if len(model.Versions) == 0 {
-			return fmt.Errorf("模型 %d (%s): 至少需要一个版本", i+1, model.Name)
+			return fmt.Errorf("%s模型 %d (%s): 至少需要一个版本", at, i+1, model.Name)
 		}
 
 		// 验证每个版本
+		seen := make(map[string]bool, len(model.Versions))
 		for j, version := range model.Versions {
-			if err := validateYamlVersion(version, model.Name, j+1); err != nil {
+			if err := validateYamlVersion(version, model.Name, j+1, requireIntro); err != nil {
 				return err
 			}
+			if version.Name != "" && seen[version.Name] {
+				return fmt.Errorf("%s模型 %d (%s): 版本号 %s 重复", sourcePrefix(version.Source), i+1, model.Name, version.Name)
+			}
+			seen[version.Name] = true
 		}
 	}
 
@@ -85,8 +203,8 @@ func ValidateYamlConfig(config *YamlConfig) error {
 }
 
 // validateYamlVersion 验证单个版本的配置
-func validateYamlVersion(version YamlVersion, modelName string, versionIndex int) error {
-	prefix := fmt.Sprintf("模型 %s, 版本 %d", modelName, versionIndex)
+func validateYamlVersion(version YamlVersion, modelName string, versionIndex int, requireIntro bool) error {
+	prefix := fmt.Sprintf("%s模型 %s, 版本 %d", sourcePrefix(version.Source), modelName, versionIndex)
 
 	// 验证 model_path 必填且文件存在
 	if version.ModelPath == "" {
@@ -125,7 +243,7 @@ func validateYamlVersion(version YamlVersion, modelName string, versionIndex int
 	}
 
 	// 验证 intro 必填（至少要有一个）
-	if !hasIntro && !hasIntroPath {
+	if requireIntro && !hasIntro && !hasIntroPath {
 		return fmt.Errorf("%s: 模型介绍（intro）是必填项，请提供介绍文本或通过 intro_path 指定介绍文件", prefix)
 	}
 
@@ -149,59 +267,20 @@ func validateYamlVersion(version YamlVersion, modelName string, versionIndex int
 // AutoIncrementVersionNames 自动为未指定 name 的版本生成递增的版本号
 // 逻辑：检查已指定的版本号中的最大值，从该值开始递增
 func AutoIncrementVersionNames(versions []YamlVersion) []YamlVersion {
-	maxVersionNum := 0
-	result := make([]YamlVersion, len(versions))
-
-	// 第一遍：复制数据并找到最大版本号
+	names := make([]string, len(versions))
 	for i, v := range versions {
-		result[i] = v
-		if v.Name != "" {
-			// 提取版本号，如 "v2.0" -> 2
-			if num := extractVersionNumber(v.Name); num > maxVersionNum {
-				maxVersionNum = num
-			}
-		}
+		names[i] = v.Name
 	}
+	names = lib.AutoIncrementVersionNames(names, nil)
 
-	// 第二遍：为空的 name 分配递增版本号
-	currentNum := maxVersionNum
-	for i := range result {
-		if result[i].Name == "" {
-			currentNum++
-			result[i].Name = fmt.Sprintf("v%d.0", currentNum)
-		}
+	result := make([]YamlVersion, len(versions))
+	for i, v := range versions {
+		result[i] = v
+		result[i].Name = names[i]
 	}
-
 	return result
 }
 
-// extractVersionNumber 从版本号字符串中提取主版本号数字
-// 例如: "v1.0" -> 1, "v2.5" -> 2, "v10.0" -> 10
-// 如果无法提取，返回 0
-func extractVersionNumber(version string) int {
-	// 匹配 vX.Y 格式的版本号
-	re := regexp.MustCompile(`^v?(\d+)\..*`)
-	matches := re.FindStringSubmatch(version)
-	if len(matches) >= 2 {
-		num, err := strconv.Atoi(matches[1])
-		if err == nil {
-			return num
-		}
-	}
-
-	// 匹配 vX 格式的版本号
-	re = regexp.MustCompile(`^v?(\d+)$`)
-	matches = re.FindStringSubmatch(version)
-	if len(matches) >= 2 {
-		num, err := strconv.Atoi(matches[1])
-		if err == nil {
-			return num
-		}
-	}
-
-	return 0
-}
-
 // GetCoverInput 获取统一的封面输入（cover_path 或 cover_url）
 func (v *YamlVersion) GetCoverInput() string {
 	if v.CoverPath != "" {
@@ -262,6 +341,108 @@ func NormalizeModelPaths(config *YamlConfig, yamlDir string) error {
 	return nil
 }
 
+// ExpandModelPaths 将含通配符的 model_path 展开为每个匹配文件一个版本（按路径排序）
+// 需在 NormalizeModelPaths 之后调用；指定 name_match 时从文件名提取版本号，否则以去掉扩展名的文件名作为版本号
+// 文件名本身含 [ 等字符且该路径存在时按普通路径处理，不展开
+func ExpandModelPaths(config *YamlConfig) error {
+	for i := range config.Models {
+		model := &config.Models[i]
+		versions := make([]YamlVersion, 0, len(model.Versions))
+		for j, ver := range model.Versions {
+			prefix := fmt.Sprintf("%s模型 %s, 版本 %d", sourcePrefix(ver.Source), model.Name, j+1)
+			if !isGlobPath(ver.ModelPath) {
+				if ver.NameMatch != "" {
+					return fmt.Errorf("%s: name_match 只能用于含通配符的 model_path", prefix)
+				}
+				versions = append(versions, ver)
+				continue
+			}
+			if ver.Name != "" {
+				return fmt.Errorf("%s: model_path 含通配符时不能指定 name，请使用 name_match 从文件名提取版本号", prefix)
+			}
+
+			var re *regexp.Regexp
+			if ver.NameMatch != "" {
+				var err error
+				if re, err = regexp.Compile(ver.NameMatch); err != nil {
+					return fmt.Errorf("%s: name_match 无效: %w", prefix, err)
+				}
+			}
+			matches, err := filepath.Glob(ver.ModelPath)
+			if err != nil {
+				return fmt.Errorf("%s: model_path 通配符无效: %w", prefix, err)
+			}
+			if len(matches) == 0 {
+				return fmt.Errorf("%s: model_path %s 没有匹配的文件", prefix, ver.ModelPath)
+			}
+			for _, path := range matches {
+				expanded := ver
+				expanded.ModelPath = path
+				expanded.NameMatch = ""
+				if re != nil {
+					if expanded.Name, err = captureVersionName(re, filepath.Base(path)); err != nil {
+						return fmt.Errorf("%s: %w", prefix, err)
+					}
+				} else {
+					expanded.Name = pathStem(path)
+				}
+				versions = append(versions, expanded)
+			}
+		}
+		model.Versions = versions
+	}
+	return nil
+}
+
+// captureVersionName 从文件名中提取版本号：优先取名为 version 的分组，其次第一个分组，没有分组时取整个匹配
+func captureVersionName(re *regexp.Regexp, name string) (string, error) {
+	m := re.FindStringSubmatch(name)
+	if m == nil {
+		return "", fmt.Errorf("文件 %s 与 name_match %s 不匹配", name, re)
+	}
+	version := m[0]
+	if idx := re.SubexpIndex("version"); idx > 0 {
+		version = m[idx]
+	} else if len(m) > 1 {
+		version = m[1]
+	}
+	if version == "" {
+		return "", fmt.Errorf("文件 %s 按 name_match %s 提取的版本号为空", name, re)
+	}
+	return version, nil
+}
+
+// sourcePrefix 错误信息前的位置（文件:行号），未知时为空
+func sourcePrefix(source string) string {
+	if source == "" {
+		return ""
+	}
+	return source + ": "
+}
+
+// hasGlobMeta 判断路径是否含通配符
+func hasGlobMeta(path string) bool {
+	return strings.ContainsAny(path, "*?[")
+}
+
+// isGlobPath 判断 model_path 是否需要按通配符展开：含通配符、不是 URL，且不是已存在的文件或目录
+func isGlobPath(path string) bool {
+	if !hasGlobMeta(path) || isURL(path) {
+		return false
+	}
+	_, err := os.Stat(path)
+	return os.IsNotExist(err)
+}
+
+// pathStem 返回文件名去掉扩展名的部分，目录返回目录名
+func pathStem(path string) string {
+	base := filepath.Base(path)
+	if lib.IsDir(path) {
+		return base
+	}
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
+
 // isURL 判断路径是否为 URL
 func isURL(path string) bool {
 	return strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://")
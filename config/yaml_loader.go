@@ -0,0 +1,210 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// YamlIncludes include 的文件，可写单个路径或列表，支持通配符
+type YamlIncludes []string
+
+// UnmarshalYAML 同时接受单个字符串和字符串列表
+func (i *YamlIncludes) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*i = YamlIncludes{node.Value}
+		return nil
+	}
+	var list []string
+	if err := node.Decode(&list); err != nil {
+		return err
+	}
+	*i = list
+	return nil
+}
+
+// yamlScope 上级文档传给 include 文件的变量与默认值
+type yamlScope struct {
+	vars     map[string]string
+	defaults []*YamlDefaults // 由近到远，路径已转为绝对路径
+}
+
+// yamlLoader 加载主配置及其 include 的文件，合并为一个配置
+type yamlLoader struct {
+	overrides map[string]string
+	config    YamlConfig
+	uploadAt  string            // upload 配置所在位置
+	stack     []string          // 正在加载的文件（绝对路径），用于检测循环引用
+	loaded    map[string]string // 已加载的文件 -> 加载它的 include 位置，同一文件只能加载一次
+}
+
+// loadFile 加载一个文件中的所有文档，from 为 include 该文件的位置（主配置为空）
+// 同一文件被多次 include 时报错：不同位置继承的 vars 与 defaults 可能不同，只加载一次会静默丢弃其中一处的设置
+func (l *yamlLoader) loadFile(path, from string, scope yamlScope) error {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		abs = path
+	}
+	for i, p := range l.stack {
+		if p == abs {
+			chain := append(append([]string{}, l.stack[i:]...), abs)
+			return fmt.Errorf("include 循环引用: %s", strings.Join(chain, " → "))
+		}
+	}
+	if prev, ok := l.loaded[abs]; ok {
+		if prev == "" {
+			prev = "主配置"
+		}
+		return fmt.Errorf("%s: %s 已被 include（%s），同一文件只能 include 一次", from, abs, prev)
+	}
+	l.loaded[abs] = from
+	l.stack = append(l.stack, abs)
+	defer func() { l.stack = l.stack[:len(l.stack)-1] }()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("读取 YAML 文件失败: %w", err)
+	}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var doc yaml.Node
+		if err := dec.Decode(&doc); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("%s: 解析 YAML 文件失败: %w", path, err)
+		}
+		if err := l.loadDocument(path, &doc, scope); err != nil {
+			return err
+		}
+	}
+}
+
+// loadDocument 加载单个文档：替换变量、填入默认值、解析相对路径，再加载其 include 的文件
+func (l *yamlLoader) loadDocument(path string, doc *yaml.Node, scope yamlScope) error {
+	if len(doc.Content) == 0 || doc.Content[0].Tag == "!!null" {
+		return nil
+	}
+	vars, err := substituteVars(doc, path, l.overrides, scope.vars)
+	if err != nil {
+		return err
+	}
+	var frag YamlConfig
+	if err := doc.Decode(&frag); err != nil {
+		return fmt.Errorf("%s: 解析 YAML 文件失败: %w", path, err)
+	}
+	annotateSources(doc.Content[0], &frag, path)
+
+	// 版本依次继承模型、本文档、上级文档的 defaults；本文档的值与版本在同一目录下，一起规范化
+	dir := filepath.Dir(path)
+	for i := range frag.Models {
+		model := &frag.Models[i]
+		for j := range model.Versions {
+			model.Defaults.applyTo(&model.Versions[j])
+			frag.Defaults.applyTo(&model.Versions[j])
+			for _, d := range scope.defaults {
+				d.applyTo(&model.Versions[j])
+			}
+		}
+	}
+	if err := NormalizeModelPaths(&frag, dir); err != nil {
+		return err
+	}
+
+	if frag.Upload != nil {
+		at := sourceAt(doc.Content[0], "upload", path)
+		if l.config.Upload != nil {
+			return fmt.Errorf("%s: upload 已在 %s 设置，只能设置一次", at, l.uploadAt)
+		}
+		l.config.Upload, l.uploadAt = frag.Upload, at
+	}
+	l.config.Models = append(l.config.Models, frag.Models...)
+
+	child := yamlScope{vars: vars, defaults: scope.defaults}
+	if frag.Defaults != nil {
+		child.defaults = append([]*YamlDefaults{frag.Defaults.normalized(dir)}, scope.defaults...)
+	}
+	at := sourceAt(doc.Content[0], "include", path)
+	for _, pattern := range frag.Include {
+		target := pattern
+		if !filepath.IsAbs(target) {
+			target = filepath.Join(dir, target)
+		}
+		matches := []string{target}
+		if hasGlobMeta(target) {
+			if matches, err = filepath.Glob(target); err != nil {
+				return fmt.Errorf("%s: include %s 无效: %w", at, pattern, err)
+			}
+			if len(matches) == 0 {
+				return fmt.Errorf("%s: include %s 没有匹配的文件", at, pattern)
+			}
+		}
+		for _, m := range matches {
+			if err := l.loadFile(m, at, child); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// annotateSources 记录每个模型与版本在文件中的位置，用于错误提示
+func annotateSources(root *yaml.Node, frag *YamlConfig, path string) {
+	models := mappingValue(root, "models")
+	if models == nil || models.Kind != yaml.SequenceNode {
+		return
+	}
+	for i, node := range models.Content {
+		if i >= len(frag.Models) {
+			break
+		}
+		model := &frag.Models[i]
+		model.Source = fmt.Sprintf("%s:%d", path, node.Line)
+		versions := mappingValue(resolveAlias(node), "versions")
+		if versions == nil || versions.Kind != yaml.SequenceNode {
+			continue
+		}
+		for j, vnode := range versions.Content {
+			if j < len(model.Versions) {
+				model.Versions[j].Source = fmt.Sprintf("%s:%d", path, vnode.Line)
+			}
+		}
+	}
+}
+
+// sourceAt 返回映射中某个键的位置，找不到时返回文件名
+func sourceAt(root *yaml.Node, key, path string) string {
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == key {
+			return fmt.Sprintf("%s:%d", path, root.Content[i].Line)
+		}
+	}
+	return path
+}
+
+// mappingValue 返回映射中某个键的值节点
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	node = resolveAlias(node)
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return resolveAlias(node.Content[i+1])
+		}
+	}
+	return nil
+}
+
+func resolveAlias(node *yaml.Node) *yaml.Node {
+	if node.Kind == yaml.AliasNode && node.Alias != nil {
+		return node.Alias
+	}
+	return node
+}
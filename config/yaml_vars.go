@@ -0,0 +1,133 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// varPattern 匹配 ${name}；$${ 表示字面量 ${
+var varPattern = regexp.MustCompile(`\$\$\{|\$\{([A-Za-z_][A-Za-z0-9_.-]*)\}`)
+
+// ParseVars 解析命令行的 --var key=value
+func ParseVars(pairs []string) (map[string]string, error) {
+	vars := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("--var 格式应为 key=value: %s", pair)
+		}
+		vars[key] = value
+	}
+	return vars, nil
+}
+
+// substituteVars 替换文档中所有标量值里的 ${name}，返回本文档可见的变量（供 include 的文件继承）
+// 查找顺序：命令行 --var、本文档 vars、上级文件的 vars、环境变量；vars 中的值不能引用同一文档的其他变量
+func substituteVars(doc *yaml.Node, file string, overrides, inherited map[string]string) (map[string]string, error) {
+	vars := make(map[string]string, len(inherited))
+	for k, v := range inherited {
+		vars[k] = v
+	}
+	lookup := func(name string) (string, bool) {
+		if v, ok := overrides[name]; ok {
+			return v, true
+		}
+		if v, ok := vars[name]; ok {
+			return v, true
+		}
+		return os.LookupEnv(name)
+	}
+	if doc.Kind == yaml.DocumentNode && len(doc.Content) > 0 {
+		doc = doc.Content[0]
+	}
+	if doc.Kind != yaml.MappingNode {
+		return vars, nil
+	}
+
+	// 先解析 vars 本身
+	var varsNode *yaml.Node
+	for i := 0; i+1 < len(doc.Content); i += 2 {
+		if doc.Content[i].Value != "vars" {
+			continue
+		}
+		varsNode = doc.Content[i+1]
+		if varsNode.Kind != yaml.MappingNode {
+			return nil, fmt.Errorf("%s:%d: vars 必须是 key: value 映射", file, varsNode.Line)
+		}
+		own := make(map[string]string, len(varsNode.Content)/2)
+		for j := 0; j+1 < len(varsNode.Content); j += 2 {
+			key, value := varsNode.Content[j], varsNode.Content[j+1]
+			if value.Kind != yaml.ScalarNode {
+				return nil, fmt.Errorf("%s:%d: 变量 %s 的值必须是字符串", file, value.Line, key.Value)
+			}
+			if err := expandScalar(value, file, lookup); err != nil {
+				return nil, err
+			}
+			own[key.Value] = value.Value
+		}
+		for k, v := range own {
+			vars[k] = v
+		}
+	}
+
+	for i := 0; i+1 < len(doc.Content); i += 2 {
+		if doc.Content[i+1] == varsNode {
+			continue
+		}
+		if err := walkScalars(doc.Content[i+1], file, lookup); err != nil {
+			return nil, err
+		}
+	}
+	return vars, nil
+}
+
+// walkScalars 替换节点下所有标量值，别名指向的节点已在锚点处替换，不重复处理
+func walkScalars(node *yaml.Node, file string, lookup func(string) (string, bool)) error {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		return expandScalar(node, file, lookup)
+	case yaml.MappingNode:
+		for i := 1; i < len(node.Content); i += 2 {
+			if err := walkScalars(node.Content[i], file, lookup); err != nil {
+				return err
+			}
+		}
+	case yaml.SequenceNode, yaml.DocumentNode:
+		for _, child := range node.Content {
+			if err := walkScalars(child, file, lookup); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// expandScalar 替换单个标量中的变量，未定义的变量报错
+func expandScalar(node *yaml.Node, file string, lookup func(string) (string, bool)) error {
+	if !strings.Contains(node.Value, "${") {
+		return nil
+	}
+	var missing string
+	node.Value = varPattern.ReplaceAllStringFunc(node.Value, func(m string) string {
+		if m == "$${" {
+			return "${"
+		}
+		name := m[2 : len(m)-1]
+		v, ok := lookup(name)
+		if !ok && missing == "" {
+			missing = name
+		}
+		return v
+	})
+	if missing != "" {
+		return fmt.Errorf("%s:%d: 未定义的变量 ${%s}，请在 vars 中定义、通过 --var %s=... 指定或设置同名环境变量", file, node.Line, missing, missing)
+	}
+	// 按替换后的值重新推断类型（未加引号的 ${PUBLIC} 可作为布尔值）
+	node.Tag = ""
+	return nil
+}
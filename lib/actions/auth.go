@@ -1,13 +1,15 @@
 package actions
 
 import (
+	"context"
+
 	"github.com/siliconflow/bizyair-cli/lib"
 	"github.com/siliconflow/bizyair-cli/meta"
 )
 
 // ExecuteLogin 执行登录操作
 // 验证API Key并保存到本地
-func ExecuteLogin(apiKey string) LoginResult {
+func ExecuteLogin(ctx context.Context, apiKey string) LoginResult {
 	if apiKey == "" {
 		return LoginResult{
 			Success: false,
@@ -17,7 +19,7 @@ func ExecuteLogin(apiKey string) LoginResult {
 
 	// 1. 验证API Key
 	client := lib.NewClient(meta.AuthDomain, apiKey)
-	_, err := client.UserInfo()
+	_, err := client.UserInfo(ctx)
 	if err != nil {
 		return LoginResult{
 			Success: false,
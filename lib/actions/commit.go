@@ -0,0 +1,79 @@
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/cloudwego/hertz/cmd/hz/util/logs"
+	"github.com/siliconflow/bizyair-cli/lib"
+	"github.com/siliconflow/bizyair-cli/meta"
+)
+
+// ExecuteCommit 使用待提交记录重新提交模型
+// 记录中的文件已上传完成，只需再次调用提交接口，成功后删除记录
+func ExecuteCommit(input CommitInput) UploadResult {
+	ctx := input.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if input.ApiKey == "" {
+		return UploadResult{
+			Success: false,
+			Errors:  []error{lib.WithStep("提交模型", lib.NewValidationError("未登录或缺少API Key"))},
+		}
+	}
+	if input.BaseDomain == "" {
+		input.BaseDomain = meta.DefaultDomain
+	}
+
+	pending, err := lib.LoadPendingCommit(input.BaseDomain, input.ModelType, input.ModelName)
+	if err != nil {
+		return UploadResult{
+			Success: false,
+			Errors:  []error{lib.WithStep("读取待提交记录", err)},
+		}
+	}
+	if pending == nil || len(pending.Versions) == 0 {
+		return UploadResult{
+			Success: false,
+			Errors: []error{lib.WithStep("读取待提交记录",
+				lib.NewValidationError(fmt.Sprintf("模型 '%s'（%s）没有待提交记录", input.ModelName, input.ModelType)))},
+		}
+	}
+
+	uploaded := pending.UploadedVersions()
+	total := len(uploaded)
+	client := lib.NewClient(input.BaseDomain, input.ApiKey)
+	if _, err := client.CommitModelV2(ctx, pending.ModelName, pending.ModelType, pending.Versions); err != nil {
+		if errors.Is(err, context.Canceled) {
+			return UploadResult{Success: false, CanceledByUser: true, TotalCount: total, PendingCommit: true}
+		}
+		// 保留记录并更新失败原因，便于下次继续提交
+		pending.LastError = err.Error()
+		_ = lib.SavePendingCommit(pending)
+		return UploadResult{
+			Success:       false,
+			TotalCount:    total,
+			Errors:        []error{lib.WithStep("提交模型", err)},
+			ModelName:     pending.ModelName,
+			ModelType:     pending.ModelType,
+			Versions:      uploaded,
+			PendingCommit: true,
+		}
+	}
+
+	if err := lib.DeletePendingCommit(input.BaseDomain, input.ModelType, input.ModelName); err != nil {
+		logs.Warnf("删除待提交记录失败: %v\n", err)
+	}
+
+	return UploadResult{
+		Success:      true,
+		SuccessCount: total,
+		TotalCount:   total,
+		ModelName:    pending.ModelName,
+		ModelType:    pending.ModelType,
+		Versions:     uploaded,
+	}
+}
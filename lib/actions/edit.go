@@ -0,0 +1,91 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/siliconflow/bizyair-cli/lib"
+	"github.com/siliconflow/bizyair-cli/meta"
+)
+
+// ExecuteEditVersion 修改已发布版本的介绍、公开状态、基础模型或封面
+// 沿用版本原有的文件签名，与模型的其余版本一起重新提交，不会重新上传模型文件
+func ExecuteEditVersion(input EditVersionInput) EditVersionResult {
+	ctx := input.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if input.ApiKey == "" {
+		return EditVersionResult{Error: lib.WithStep("修改版本", lib.NewValidationError("未登录或缺少API Key"))}
+	}
+	if input.BaseDomain == "" {
+		input.BaseDomain = meta.DefaultDomain
+	}
+	if input.Introduction == nil && input.Public == nil && input.BaseModel == nil && input.CoverUrl == "" {
+		return EditVersionResult{Error: lib.WithStep("修改版本", lib.NewValidationError("没有需要修改的内容"))}
+	}
+	if input.Introduction != nil && strings.TrimSpace(*input.Introduction) == "" {
+		return EditVersionResult{Error: lib.WithStep("参数验证", lib.NewValidationError("模型介绍（intro）不能为空"))}
+	}
+	if input.BaseModel != nil {
+		if err := lib.ValidateBaseModel(*input.BaseModel); err != nil {
+			return EditVersionResult{Error: lib.WithStep("参数验证", fmt.Errorf("基础模型无效: %w", err))}
+		}
+	}
+
+	// 1. 查询模型详情，定位要修改的版本
+	client := lib.NewClient(input.BaseDomain, input.ApiKey)
+	detail, err := getModelDetail(ctx, client, input.ApiKey, input.BaseDomain, input.ModelId, nil)
+	if err != nil {
+		return EditVersionResult{Error: lib.WithStep("查询模型详情", err)}
+	}
+	current, err := FindDetailVersion(*detail, input.Version)
+	if err != nil {
+		return EditVersionResult{Error: lib.WithStep("查找版本", err)}
+	}
+
+	// 2. 以现有信息为基础应用修改，签名沿用原值
+	versions := remoteVersions(detail)
+	if err := checkRemoteFiles(versions, nil); err != nil {
+		return EditVersionResult{Error: lib.WithStep("修改版本", err)}
+	}
+	var version *lib.ModelVersion
+	for _, ver := range versions {
+		if ver.Version == current.Version {
+			version = ver
+			break
+		}
+	}
+	if input.Introduction != nil {
+		version.Introduction = *input.Introduction
+	}
+	if input.Public != nil {
+		version.Public = *input.Public
+	}
+	if input.BaseModel != nil {
+		version.BaseModel = *input.BaseModel
+	}
+
+	// 3. 上传新封面
+	if input.CoverUrl != "" {
+		coverUrl, err := lib.UploadCover(ctx, client, input.CoverUrl, input.OnCoverStatus)
+		if err != nil {
+			return EditVersionResult{Error: lib.WithStep("封面上传", err)}
+		}
+		version.CoverUrls = []string{coverUrl}
+	}
+
+	// 4. 连同其余版本一起提交，其余版本保持原样
+	if _, err := client.CommitModelV2(ctx, detail.Name, detail.Type, versions); err != nil {
+		return EditVersionResult{Error: lib.WithStep("提交模型", err)}
+	}
+
+	return EditVersionResult{
+		ModelId:   detail.Id,
+		ModelName: detail.Name,
+		ModelType: detail.Type,
+		Version:   version,
+	}
+}
@@ -1,6 +1,11 @@
 package actions
 
 import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
 	"github.com/siliconflow/bizyair-cli/lib"
 	"github.com/siliconflow/bizyair-cli/meta"
 )
@@ -27,6 +32,10 @@ func ListModels(input ListModelsInput) ListModelsResult {
 	if input.Sort == "" {
 		input.Sort = "Recently"
 	}
+	ctx := input.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
 
 	// 构建模型类型列表
 	var modelTypes []string
@@ -44,6 +53,7 @@ func ListModels(input ListModelsInput) ListModelsResult {
 	// 调用API
 	client := lib.NewClient(input.BaseDomain, input.ApiKey)
 	resp, err := client.ListModel(
+		ctx,
 		input.Current,
 		input.PageSize,
 		input.Keyword,
@@ -63,8 +73,41 @@ func ListModels(input ListModelsInput) ListModelsResult {
 	}
 }
 
+// ListAllModels 按页查询模型列表，直到取满 Total 条
+// input.Current 为起始页（默认 1），input.PageSize 为每页条数（默认 100）
+func ListAllModels(input ListModelsInput) ListModelsResult {
+	if input.Current == 0 {
+		input.Current = 1
+	}
+	if input.PageSize == 0 {
+		input.PageSize = 100
+	}
+
+	var models []*lib.BizyModelInfo
+	total := 0
+	for {
+		result := ListModels(input)
+		if result.Error != nil {
+			return ListModelsResult{Models: models, Total: total, Error: result.Error}
+		}
+		total = result.Total
+		models = append(models, result.Models...)
+
+		// 本页为空或已取满，结束翻页
+		if len(result.Models) == 0 || len(models) >= total {
+			break
+		}
+		input.Current++
+	}
+
+	return ListModelsResult{
+		Models: models,
+		Total:  total,
+	}
+}
+
 // GetModelDetail 获取模型详情
-func GetModelDetail(apiKey, baseDomain string, modelId int64) ModelDetailResult {
+func GetModelDetail(ctx context.Context, apiKey, baseDomain string, modelId int64) ModelDetailResult {
 	if apiKey == "" {
 		return ModelDetailResult{
 			Error: lib.WithStep("查询模型详情", lib.NewValidationError("未登录或缺少API Key")),
@@ -76,26 +119,110 @@ func GetModelDetail(apiKey, baseDomain string, modelId int64) ModelDetailResult
 	}
 
 	client := lib.NewClient(baseDomain, apiKey)
-	resp, err := client.GetBizyModelDetail(modelId)
+	detail, err := getModelDetail(ctx, client, apiKey, baseDomain, modelId, nil)
 	if err != nil {
 		return ModelDetailResult{
 			Error: lib.WithStep("查询模型详情", err),
 		}
 	}
 
+	return ModelDetailResult{
+		Detail: detail,
+	}
+}
+
+// getModelDetail 查询模型详情，并按版本 id 填入列表接口返回的公开状态（详情接口不返回 public）
+// info 为列表接口中的该模型，为 nil 时按名称和类型查找
+func getModelDetail(ctx context.Context, client *lib.Client, apiKey, baseDomain string, modelId int64, info *lib.BizyModelInfo) (*lib.BizyModelDetail, error) {
+	resp, err := client.GetBizyModelDetail(ctx, modelId)
+	if err != nil {
+		return nil, err
+	}
 	if resp == nil || resp.Data.Id == 0 {
-		return ModelDetailResult{
-			Error: lib.WithStep("查询模型详情", lib.NewValidationError("未获取到模型详情")),
+		return nil, lib.NewValidationError("未获取到模型详情")
+	}
+	detail := resp.Data
+
+	if info == nil {
+		matched, err := FindModelsByName(ctx, apiKey, baseDomain, detail.Name, detail.Type)
+		if err != nil {
+			return nil, fmt.Errorf("查询版本公开状态失败: %w", err)
+		}
+		for _, model := range matched {
+			if model.Id == detail.Id {
+				info = model
+				break
+			}
+		}
+		if info == nil {
+			return nil, lib.NewValidationError(fmt.Sprintf("模型列表中未找到模型 '%s'（id=%d），无法确定版本公开状态", detail.Name, detail.Id))
 		}
 	}
 
-	return ModelDetailResult{
-		Detail: &resp.Data,
+	public := make(map[int64]bool, len(info.Versions))
+	for _, ver := range info.Versions {
+		public[ver.Id] = ver.Public
+	}
+	for i := range detail.Versions {
+		p, ok := public[detail.Versions[i].Id]
+		if !ok {
+			return nil, lib.NewValidationError(fmt.Sprintf("模型列表中未找到版本 '%s'（id=%d），无法确定版本公开状态", detail.Versions[i].Version, detail.Versions[i].Id))
+		}
+		detail.Versions[i].Public = p
 	}
+	return &detail, nil
+}
+
+// remoteVersions 以远端详情重建模型现有的版本，沿用原有的签名、路径、介绍、封面与公开状态
+// 提交模型时带上全部版本，不依赖提交接口是否按版本号合并
+func remoteVersions(detail *lib.BizyModelDetail) []*lib.ModelVersion {
+	versions := make([]*lib.ModelVersion, 0, len(detail.Versions))
+	for _, ver := range detail.Versions {
+		versions = append(versions, &lib.ModelVersion{
+			Version:      ver.Version,
+			BaseModel:    ver.BaseModel,
+			Introduction: ver.Intro,
+			Public:       ver.Public,
+			Sign:         ver.Sign,
+			Path:         ver.Path,
+			CoverUrls:    ver.CoverUrls,
+		})
+	}
+	return versions
+}
+
+// checkRemoteFiles 检查以远端详情重建的版本能否原样重新提交
+// 目录版本没有签名，其文件列表无法从详情中获得，重新提交会丢失其文件；skip 中的版本会被本次提交替换或删除，不检查
+func checkRemoteFiles(versions []*lib.ModelVersion, skip map[string]bool) error {
+	for _, ver := range versions {
+		if ver.Sign == "" && !skip[ver.Version] {
+			return lib.NewValidationError(fmt.Sprintf("版本 %s 没有文件签名（目录版本），其文件列表无法从详情中获得，重新提交模型会丢失其文件", ver.Version))
+		}
+	}
+	return nil
+}
+
+// mergeVersions 用 updates 替换 base 中的同名版本，其余新版本按顺序追加到末尾
+func mergeVersions(base, updates []*lib.ModelVersion) []*lib.ModelVersion {
+	index := make(map[string]int, len(base))
+	merged := make([]*lib.ModelVersion, 0, len(base)+len(updates))
+	for _, ver := range base {
+		index[ver.Version] = len(merged)
+		merged = append(merged, ver)
+	}
+	for _, ver := range updates {
+		if i, ok := index[ver.Version]; ok {
+			merged[i] = ver
+			continue
+		}
+		index[ver.Version] = len(merged)
+		merged = append(merged, ver)
+	}
+	return merged
 }
 
 // DeleteModel 删除模型
-func DeleteModel(apiKey, baseDomain string, modelId int64) DeleteModelResult {
+func DeleteModel(ctx context.Context, apiKey, baseDomain string, modelId int64) DeleteModelResult {
 	if apiKey == "" {
 		return DeleteModelResult{
 			Success: false,
@@ -108,7 +235,7 @@ func DeleteModel(apiKey, baseDomain string, modelId int64) DeleteModelResult {
 	}
 
 	client := lib.NewClient(baseDomain, apiKey)
-	_, err := client.DeleteBizyModelById(modelId)
+	_, err := client.DeleteBizyModelById(ctx, modelId)
 	if err != nil {
 		return DeleteModelResult{
 			Success: false,
@@ -120,3 +247,163 @@ func DeleteModel(apiKey, baseDomain string, modelId int64) DeleteModelResult {
 		Success: true,
 	}
 }
+
+// FindDetailVersion 按版本号查找版本，未指定版本号时要求模型只有一个版本
+func FindDetailVersion(detail lib.BizyModelDetail, version string) (*lib.BizyModelDetailVersion, error) {
+	names := make([]string, 0, len(detail.Versions))
+	for i := range detail.Versions {
+		if version != "" && detail.Versions[i].Version == version {
+			return &detail.Versions[i], nil
+		}
+		names = append(names, detail.Versions[i].Version)
+	}
+
+	if version == "" && len(detail.Versions) == 1 {
+		return &detail.Versions[0], nil
+	}
+	if version == "" {
+		return nil, lib.NewValidationError(fmt.Sprintf("模型 '%s' 有多个版本（%s），请通过 --version 指定", detail.Name, strings.Join(names, ", ")))
+	}
+	return nil, lib.NewValidationError(fmt.Sprintf("模型 '%s' 不存在版本 '%s'，已有版本: %s", detail.Name, version, strings.Join(names, ", ")))
+}
+
+// DeleteModelVersions 删除模型的部分版本
+// 删除接口只能删除整个模型：先按名称与类型删除模型，再以原有签名重新提交其余版本（文件无需重新上传）
+// 重新提交失败时保存待提交记录，可通过 commit 命令恢复其余版本
+// 重新提交得到的是新模型，模型 ID 与使用统计会随之变化，结果中返回新的模型 ID
+func DeleteModelVersions(ctx context.Context, apiKey, baseDomain string, modelId int64, versions []string) DeleteModelResult {
+	if apiKey == "" {
+		return DeleteModelResult{
+			Success: false,
+			Error:   lib.WithStep("删除版本", lib.NewValidationError("未登录或缺少API Key")),
+		}
+	}
+
+	if baseDomain == "" {
+		baseDomain = meta.DefaultDomain
+	}
+
+	client := lib.NewClient(baseDomain, apiKey)
+	detail, err := getModelDetail(ctx, client, apiKey, baseDomain, modelId, nil)
+	if err != nil {
+		return DeleteModelResult{Success: false, Error: lib.WithStep("查询模型详情", err)}
+	}
+
+	removed := make(map[string]bool, len(versions))
+	for _, name := range versions {
+		if _, err := FindDetailVersion(*detail, name); err != nil {
+			return DeleteModelResult{Success: false, Error: lib.WithStep("查找版本", err)}
+		}
+		removed[name] = true
+	}
+	existing := remoteVersions(detail)
+	if err := checkRemoteFiles(existing, removed); err != nil {
+		return DeleteModelResult{Success: false, Error: lib.WithStep("删除版本", err)}
+	}
+	var remaining []*lib.ModelVersion
+	for _, ver := range existing {
+		if !removed[ver.Version] {
+			remaining = append(remaining, ver)
+		}
+	}
+	if len(remaining) == 0 {
+		return DeleteModelResult{Success: false, Error: lib.WithStep("删除版本", lib.NewValidationError(
+			fmt.Sprintf("将删除模型 '%s' 的全部版本，请直接删除整个模型", detail.Name)))}
+	}
+
+	if _, err := client.RemoveModel(ctx, detail.Type, detail.Name); err != nil {
+		return DeleteModelResult{Success: false, Error: lib.WithStep("删除版本", err)}
+	}
+	// 模型已删除，此时中断会丢失其余版本：重新提交不随命令取消
+	commitCtx := context.WithoutCancel(ctx)
+	if _, err := client.CommitModelV2(commitCtx, detail.Name, detail.Type, remaining); err != nil {
+		pending := &lib.PendingCommit{
+			BaseDomain: baseDomain,
+			ModelName:  detail.Name,
+			ModelType:  detail.Type,
+			Versions:   remaining,
+			LastError:  err.Error(),
+		}
+		if serr := lib.SavePendingCommit(pending); serr != nil {
+			return DeleteModelResult{Success: false, Error: lib.WithStep("重新提交其余版本",
+				fmt.Errorf("%w（保存待提交记录也失败: %v）", err, serr))}
+		}
+		return DeleteModelResult{Success: false, Error: lib.WithStep("重新提交其余版本",
+			fmt.Errorf("%w，其余版本已保存为待提交记录，可运行 `bizyair %s -n '%s' -t %s` 恢复", err, meta.CmdCommit, detail.Name, detail.Type))}
+	}
+
+	// 新的模型 ID 只用于展示，查询失败时为 0
+	result := DeleteModelResult{Success: true}
+	if matched, err := FindModelsByName(ctx, apiKey, baseDomain, detail.Name, detail.Type); err == nil && len(matched) == 1 {
+		result.ModelId = matched[0].Id
+	}
+	return result
+}
+
+// FindModelsByName 在所有分页中按名称精确查找模型
+// modelType 为空时匹配所有类型
+func FindModelsByName(ctx context.Context, apiKey, baseDomain, name, modelType string) ([]*lib.BizyModelInfo, error) {
+	result := ListAllModels(ListModelsInput{
+		ApiKey:     apiKey,
+		BaseDomain: baseDomain,
+		ModelType:  modelType,
+		Keyword:    name,
+		Context:    ctx,
+	})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	var matched []*lib.BizyModelInfo
+	for _, model := range result.Models {
+		if model.Name == name && (modelType == "" || model.Type == modelType) {
+			matched = append(matched, model)
+		}
+	}
+	return matched, nil
+}
+
+// ResolveModelId 将模型 ID 或模型名称解析为模型 ID
+// target 为纯数字时视为模型 ID；同时指定了类型时核对模型类型，不一致则按名称（模型名也可能是纯数字）查找
+// 其余情况按名称（及类型）精确匹配
+func ResolveModelId(ctx context.Context, apiKey, baseDomain, target, modelType string) (int64, error) {
+	if target == "" {
+		return 0, lib.WithStep("查找模型", lib.NewValidationError("请指定模型 ID 或名称"))
+	}
+	numeric := false
+	if id, err := strconv.ParseInt(target, 10, 64); err == nil && id > 0 {
+		if modelType == "" {
+			return id, nil
+		}
+		if baseDomain == "" {
+			baseDomain = meta.DefaultDomain
+		}
+		resp, err := lib.NewClient(baseDomain, apiKey).GetBizyModelDetail(ctx, id)
+		if err == nil && resp.Data.Type == modelType {
+			return id, nil
+		}
+		numeric = true
+	}
+
+	matched, err := FindModelsByName(ctx, apiKey, baseDomain, target, modelType)
+	if err != nil {
+		return 0, lib.WithStep("查找模型", err)
+	}
+	switch len(matched) {
+	case 0:
+		if numeric {
+			return 0, lib.WithStep("查找模型", lib.NewValidationError(
+				fmt.Sprintf("未找到 ID 为 %s 的 %s 模型，也没有名为 '%s' 的 %s 模型", target, modelType, target, modelType)))
+		}
+		return 0, lib.WithStep("查找模型", lib.NewValidationError(fmt.Sprintf("未找到模型 '%s'", target)))
+	case 1:
+		return matched[0].Id, nil
+	default:
+		types := make([]string, 0, len(matched))
+		for _, model := range matched {
+			types = append(types, fmt.Sprintf("%s(id=%d)", model.Type, model.Id))
+		}
+		return 0, lib.WithStep("查找模型", lib.NewValidationError(
+			fmt.Sprintf("存在多个名为 '%s' 的模型: %s，请通过 --type 指定类型或直接使用模型 ID", target, strings.Join(types, ", "))))
+	}
+}
@@ -0,0 +1,244 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/siliconflow/bizyair-cli/lib"
+	"github.com/siliconflow/bizyair-cli/meta"
+)
+
+// PlanModel 比较配置中的模型与远端模型，生成变更计划
+// 文件以签名比较（目录版本不比较文件内容）；封面只比较 URL，本地封面文件上传时会转码，无法与远端比较
+func PlanModel(input PlanInput) ModelPlan {
+	ctx := input.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	plan := ModelPlan{Name: input.Model.Name, Type: input.Model.Type}
+
+	if input.ApiKey == "" {
+		plan.Error = lib.WithStep("比较模型", lib.NewValidationError("未登录或缺少API Key"))
+		return plan
+	}
+	if input.BaseDomain == "" {
+		input.BaseDomain = meta.DefaultDomain
+	}
+
+	// 1. 查找远端模型，不存在时整体新建
+	matched, err := FindModelsByName(ctx, input.ApiKey, input.BaseDomain, input.Model.Name, input.Model.Type)
+	if err != nil {
+		plan.Error = lib.WithStep("查找模型", err)
+		return plan
+	}
+	if len(matched) == 0 {
+		plan.Create = true
+		for i := range input.Model.Versions {
+			if err := requireIntro(&input.Model.Versions[i]); err != nil {
+				plan.Error = err
+				return plan
+			}
+			plan.Versions = append(plan.Versions, VersionPlan{
+				Version: input.Model.Versions[i].Version,
+				Action:  VersionAdd,
+				Desired: &input.Model.Versions[i],
+			})
+		}
+		return plan
+	}
+
+	client := lib.NewClient(input.BaseDomain, input.ApiKey)
+	detail, err := getModelDetail(ctx, client, input.ApiKey, input.BaseDomain, matched[0].Id, matched[0])
+	if err != nil {
+		plan.Error = lib.WithStep("查询模型详情", err)
+		return plan
+	}
+	plan.ModelId = detail.Id
+	plan.Remote = detail
+
+	remote := make(map[string]*lib.BizyModelDetailVersion, len(detail.Versions))
+	for i := range detail.Versions {
+		remote[detail.Versions[i].Version] = &detail.Versions[i]
+	}
+
+	// 2. 逐个比较配置中的版本
+	declared := make(map[string]bool, len(input.Model.Versions))
+	for i := range input.Model.Versions {
+		desired := &input.Model.Versions[i]
+		declared[desired.Version] = true
+		current, ok := remote[desired.Version]
+		if !ok {
+			if err := requireIntro(desired); err != nil {
+				plan.Error = err
+				return plan
+			}
+			plan.Versions = append(plan.Versions, VersionPlan{Version: desired.Version, Action: VersionAdd, Desired: desired})
+			continue
+		}
+		vp, err := planVersion(ctx, input, desired, current)
+		if err != nil {
+			plan.Error = lib.WithStep(fmt.Sprintf("比较版本 %s", desired.Version), err)
+			return plan
+		}
+		plan.Versions = append(plan.Versions, vp)
+	}
+
+	// 3. 远端有但配置中没有的版本
+	for i := range detail.Versions {
+		if !declared[detail.Versions[i].Version] {
+			plan.Versions = append(plan.Versions, VersionPlan{
+				Version: detail.Versions[i].Version,
+				Action:  VersionRemove,
+				Remote:  &detail.Versions[i],
+			})
+		}
+	}
+	return plan
+}
+
+// planVersion 比较已存在的版本：文件签名变化时重新上传，否则只修改变化的信息
+func planVersion(ctx context.Context, input PlanInput, desired *DesiredVersion, current *lib.BizyModelDetailVersion) (VersionPlan, error) {
+	vp := VersionPlan{Version: desired.Version, Action: VersionUnchanged, Desired: desired, Remote: current}
+
+	if desired.Introduction != "" && strings.TrimSpace(desired.Introduction) != strings.TrimSpace(current.Intro) {
+		vp.IntroChanged = true
+		vp.Changes = append(vp.Changes, fmt.Sprintf("intro: %d → %d 字", len([]rune(current.Intro)), len([]rune(desired.Introduction))))
+	}
+	if desired.Public != nil && *desired.Public != current.Public {
+		vp.PublicChanged = true
+		vp.Changes = append(vp.Changes, fmt.Sprintf("public: %t → %t", current.Public, *desired.Public))
+	}
+	if desired.BaseModel != "" && desired.BaseModel != current.BaseModel {
+		vp.BaseModelChanged = true
+		vp.Changes = append(vp.Changes, fmt.Sprintf("base_model: %s → %s", orDash(current.BaseModel), desired.BaseModel))
+	}
+	if lib.IsHTTPURL(desired.CoverUrl) && (len(current.CoverUrls) == 0 || current.CoverUrls[0] != desired.CoverUrl) {
+		vp.CoverChanged = true
+		vp.Changes = append(vp.Changes, "cover_url 变化")
+	}
+
+	// 远端没有签名或目录版本时不比较文件
+	stat, err := os.Stat(desired.Path)
+	if err != nil {
+		return vp, err
+	}
+	if current.Sign != "" && !stat.IsDir() {
+		if input.OnStatus != nil {
+			input.OnStatus(fmt.Sprintf("计算文件签名: %s", desired.Path))
+		}
+		sign, err := lib.FileSignature(ctx, desired.Path, !input.NoHashCache, nil)
+		if err != nil {
+			return vp, err
+		}
+		if sign != current.Sign {
+			vp.Action = VersionReupload
+			vp.Changes = append([]string{fmt.Sprintf("文件签名: %s → %s", shortSign(current.Sign), shortSign(sign))}, vp.Changes...)
+			return vp, nil
+		}
+	}
+
+	if len(vp.Changes) > 0 {
+		vp.Action = VersionEdit
+	}
+	return vp, nil
+}
+
+// Count 返回指定变更类型的版本数
+func (p *ModelPlan) Count(action string) int {
+	n := 0
+	for _, v := range p.Versions {
+		if v.Action == action {
+			n++
+		}
+	}
+	return n
+}
+
+// HasChanges 是否需要变更，prune 为 false 时不计远端多出的版本
+func (p *ModelPlan) HasChanges(prune bool) bool {
+	for _, v := range p.Versions {
+		switch v.Action {
+		case VersionAdd, VersionReupload, VersionEdit:
+			return true
+		case VersionRemove:
+			if prune {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// UploadVersions 返回需要上传文件的版本（新增与重新上传）
+// 配置中未声明的 intro、public、base_model 沿用远端的值
+func (p *ModelPlan) UploadVersions() []VersionInput {
+	var versions []VersionInput
+	for _, v := range p.Versions {
+		if v.Action != VersionAdd && v.Action != VersionReupload {
+			continue
+		}
+		ver := VersionInput{
+			Version:      v.Desired.Version,
+			Path:         v.Desired.Path,
+			BaseModel:    v.Desired.BaseModel,
+			Introduction: v.Desired.Introduction,
+			CoverUrl:     v.Desired.CoverUrl,
+		}
+		if v.Desired.Public != nil {
+			ver.Public = *v.Desired.Public
+		} else if v.Remote != nil {
+			ver.Public = v.Remote.Public
+		}
+		if ver.BaseModel == "" && v.Remote != nil {
+			ver.BaseModel = v.Remote.BaseModel
+		}
+		if ver.Introduction == "" && v.Remote != nil {
+			ver.Introduction = v.Remote.Intro
+		}
+		versions = append(versions, ver)
+	}
+	return versions
+}
+
+// EditInput 返回仅修改信息的版本对应的修改参数，只包含变化的字段
+func (v *VersionPlan) EditInput() EditVersionInput {
+	input := EditVersionInput{Version: v.Version}
+	if v.IntroChanged {
+		input.Introduction = &v.Desired.Introduction
+	}
+	if v.PublicChanged {
+		input.Public = v.Desired.Public
+	}
+	if v.BaseModelChanged {
+		input.BaseModel = &v.Desired.BaseModel
+	}
+	if v.CoverChanged {
+		input.CoverUrl = v.Desired.CoverUrl
+	}
+	return input
+}
+
+// requireIntro 新增的版本没有远端介绍可沿用，必须在配置中指定
+func requireIntro(desired *DesiredVersion) error {
+	if desired.Introduction == "" {
+		return lib.WithStep("比较模型", lib.NewValidationError(
+			fmt.Sprintf("版本 %s 为新增版本，需要指定 intro 或 intro_path", desired.Version)))
+	}
+	return nil
+}
+
+func shortSign(sign string) string {
+	if len(sign) > 8 {
+		return sign[:8]
+	}
+	return sign
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
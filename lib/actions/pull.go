@@ -0,0 +1,215 @@
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/siliconflow/bizyair-cli/lib"
+	"github.com/siliconflow/bizyair-cli/lib/filehash"
+	"github.com/siliconflow/bizyair-cli/meta"
+)
+
+// ExecutePull 下载模型版本的文件到本地，并按版本签名校验
+// 下载中断时保留 .part 文件，再次执行时从中断处继续
+func ExecutePull(input PullInput) PullResult {
+	ctx := input.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if input.ApiKey == "" {
+		return PullResult{Error: lib.WithStep("下载模型", lib.NewValidationError("未登录或缺少API Key"))}
+	}
+	if input.BaseDomain == "" {
+		input.BaseDomain = meta.DefaultDomain
+	}
+	if input.Dir == "" {
+		input.Dir = "."
+	}
+	status := func(message string) {
+		if input.OnStatus != nil {
+			input.OnStatus(message)
+		}
+	}
+
+	// 1. 查询模型详情，定位要下载的版本
+	client := lib.NewClient(input.BaseDomain, input.ApiKey)
+	resp, err := client.GetBizyModelDetail(ctx, input.ModelId)
+	if err != nil {
+		return PullResult{Error: lib.WithStep("查询模型详情", err)}
+	}
+	detail := resp.Data
+	ver, err := FindDetailVersion(detail, input.Version)
+	if err != nil {
+		return PullResult{Error: lib.WithStep("查找版本", err)}
+	}
+	result := PullResult{
+		ModelId:   detail.Id,
+		ModelName: detail.Name,
+		ModelType: detail.Type,
+		Version:   ver.Version,
+		FileSize:  ver.FileSize,
+	}
+
+	// 2. 下载地址只在列表接口中返回
+	fileUrl, err := findVersionFileUrl(ctx, input.ApiKey, input.BaseDomain, detail, ver)
+	if err != nil {
+		result.Error = lib.WithStep("获取下载地址", err)
+		return result
+	}
+
+	if err := os.MkdirAll(input.Dir, 0755); err != nil {
+		result.Error = lib.WithStep("创建目录", err)
+		return result
+	}
+	fileName := filepath.Base(ver.FileName)
+	if ver.FileName == "" {
+		fileName = urlBase(fileUrl)
+	}
+	if fileName == "" || fileName == "." || fileName == "/" {
+		result.Error = lib.WithStep("获取下载地址", fmt.Errorf("无法确定版本 %s 的文件名", ver.Version))
+		return result
+	}
+	result.FilePath = filepath.Join(input.Dir, fileName)
+
+	// 3. 已存在的文件校验通过则跳过下载；版本没有签名时无法确认文件是否一致，重新下载
+	_, statErr := os.Stat(result.FilePath)
+	if statErr == nil && ver.Sign == "" {
+		status(fmt.Sprintf("文件 %s 已存在，但版本没有签名信息无法校验，重新下载", result.FilePath))
+	}
+	if statErr == nil && ver.Sign != "" {
+		status(fmt.Sprintf("文件已存在，校验 %s", result.FilePath))
+		ok, err := verifySign(ctx, result.FilePath, ver.Sign, input.OnVerifyProgress)
+		if err != nil {
+			result.Error = lib.WithStep("校验文件", err)
+			return result
+		}
+		if !ok {
+			result.Error = lib.WithStep("校验文件", fmt.Errorf("文件 %s 已存在且与版本签名不一致，请删除或更换保存目录后重试", result.FilePath))
+			return result
+		}
+		result.Skipped = true
+		result.Verified = true
+	} else {
+		status(fmt.Sprintf("下载 %s", fileName))
+		err := lib.DownloadFile(lib.DownloadFileOptions{
+			URL:          fileUrl,
+			DestPath:     result.FilePath,
+			ProgressFunc: input.OnDownloadProgress,
+			Context:      ctx,
+			Parallel:     meta.DownloadParallel,
+		})
+		if err != nil {
+			if errors.Is(err, context.Canceled) {
+				result.Error = lib.WithStep("下载文件", err)
+			} else {
+				result.Error = lib.WithStep("下载文件", fmt.Errorf("%w（已下载的部分保存在 %s，重新执行可继续下载）", err, result.FilePath+meta.DownloadPartSuffix))
+			}
+			return result
+		}
+
+		// 4. 按版本签名校验，不一致时删除文件避免误用
+		if ver.Sign != "" {
+			status("校验文件签名")
+			ok, err := verifySign(ctx, result.FilePath, ver.Sign, input.OnVerifyProgress)
+			if err != nil {
+				result.Error = lib.WithStep("校验文件", err)
+				return result
+			}
+			if !ok {
+				os.Remove(result.FilePath)
+				result.Error = lib.WithStep("校验文件", fmt.Errorf("下载的文件与版本签名不一致，已删除，请重试"))
+				return result
+			}
+			result.Verified = true
+		}
+	}
+	if ver.Sign == "" {
+		status("⚠ 版本没有签名信息，跳过校验")
+	}
+
+	// 5. 封面与介绍保存在模型文件旁边
+	stem := strings.TrimSuffix(result.FilePath, filepath.Ext(result.FilePath))
+	if input.WithCovers {
+		for i, coverUrl := range ver.CoverUrls {
+			ext := path.Ext(urlBase(coverUrl))
+			if ext == "" {
+				ext = ".png"
+			}
+			coverPath := stem + ".cover" + ext
+			if i > 0 {
+				coverPath = fmt.Sprintf("%s.cover-%d%s", stem, i+1, ext)
+			}
+			if _, err := os.Stat(coverPath); err == nil {
+				// 封面没有签名无法校验，已存在时沿用，需要更新时删除后重新执行
+				status(fmt.Sprintf("封面 %s 已存在，跳过下载", filepath.Base(coverPath)))
+				result.CoverPaths = append(result.CoverPaths, coverPath)
+				continue
+			}
+			status(fmt.Sprintf("下载封面 %s", filepath.Base(coverPath)))
+			if err := lib.DownloadFile(lib.DownloadFileOptions{URL: coverUrl, DestPath: coverPath, Context: ctx}); err != nil {
+				result.Error = lib.WithStep("下载封面", err)
+				return result
+			}
+			result.CoverPaths = append(result.CoverPaths, coverPath)
+		}
+	}
+	if input.WithIntro && ver.Intro != "" {
+		result.IntroPath = stem + ".md"
+		if err := os.WriteFile(result.IntroPath, []byte(ver.Intro), 0644); err != nil {
+			result.Error = lib.WithStep("写入介绍", err)
+			return result
+		}
+	}
+
+	return result
+}
+
+// findVersionFileUrl 从模型列表中查找版本的下载地址
+func findVersionFileUrl(ctx context.Context, apiKey, baseDomain string, detail lib.BizyModelDetail, ver *lib.BizyModelDetailVersion) (string, error) {
+	models, err := FindModelsByName(ctx, apiKey, baseDomain, detail.Name, detail.Type)
+	if err != nil {
+		return "", err
+	}
+	for _, model := range models {
+		if model.Id != detail.Id {
+			continue
+		}
+		for _, v := range model.Versions {
+			if (v.Id != 0 && v.Id == ver.Id) || v.Version == ver.Version {
+				if v.FileUrl == "" {
+					return "", fmt.Errorf("版本 %s 没有可下载的文件", ver.Version)
+				}
+				return v.FileUrl, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("未找到版本 %s 的下载地址", ver.Version)
+}
+
+// verifySign 重新计算文件签名并与版本签名比较，sign 为空时无法校验，视为不一致
+func verifySign(ctx context.Context, filePath, sign string, progress func(consumed, total int64)) (bool, error) {
+	if sign == "" {
+		return false, nil
+	}
+	got, _, err := filehash.CalculateHashCtx(ctx, filePath, progress)
+	if err != nil {
+		return false, err
+	}
+	return got == sign, nil
+}
+
+// urlBase 返回 URL 路径的最后一段（忽略查询参数）
+func urlBase(rawUrl string) string {
+	u, err := url.Parse(rawUrl)
+	if err != nil || u.Path == "" {
+		return ""
+	}
+	return path.Base(u.Path)
+}
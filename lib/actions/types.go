@@ -24,6 +24,7 @@ type ListModelsInput struct {
 	Sort       string
 	Current    int
 	PageSize   int
+	Context    context.Context // 用于取消操作，为空时使用 Background
 }
 
 // ListModelsResult 查询模型列表的结果
@@ -39,9 +40,134 @@ type ModelDetailResult struct {
 	Error  error
 }
 
+// EditVersionInput 修改版本信息的输入参数
+// 指针字段为 nil、CoverUrl 为空时保持原值不变
+type EditVersionInput struct {
+	ApiKey       string
+	BaseDomain   string
+	ModelId      int64
+	Version      string // 要修改的版本号，模型只有一个版本时可为空
+	Introduction *string
+	Public       *bool
+	BaseModel    *string
+	CoverUrl     string          // 新封面（本地文件或 URL）
+	Context      context.Context // 用于取消操作
+
+	// OnCoverStatus 封面处理状态回调（可选）
+	OnCoverStatus func(status, message string)
+}
+
+// EditVersionResult 修改版本信息的结果
+type EditVersionResult struct {
+	ModelId   int64
+	ModelName string
+	ModelType string
+	Version   *lib.ModelVersion // 提交后的版本信息
+	Error     error
+}
+
+// PullInput 下载模型版本的输入参数
+type PullInput struct {
+	ApiKey     string
+	BaseDomain string
+	ModelId    int64
+	Version    string          // 要下载的版本号，模型只有一个版本时可为空
+	Dir        string          // 保存目录，默认为当前目录
+	WithCovers bool            // 同时下载封面
+	WithIntro  bool            // 同时将版本介绍写入同名 .md 文件
+	Context    context.Context // 用于取消操作
+
+	// OnStatus 阶段状态回调（可选）
+	OnStatus func(message string)
+	// OnDownloadProgress 模型文件下载进度回调（可选）
+	OnDownloadProgress func(downloaded, total int64)
+	// OnVerifyProgress 文件校验进度回调（可选）
+	OnVerifyProgress func(consumed, total int64)
+}
+
+// PullResult 下载模型版本的结果
+type PullResult struct {
+	ModelId    int64
+	ModelName  string
+	ModelType  string
+	Version    string
+	FilePath   string
+	FileSize   int64
+	Verified   bool     // 已按版本签名校验通过
+	Skipped    bool     // 文件已存在且校验通过，未重新下载
+	CoverPaths []string // 封面文件，已存在的封面不重新下载
+	IntroPath  string   // 已写入的介绍文件
+	Error      error
+}
+
+// DesiredVersion 配置中声明的版本
+type DesiredVersion struct {
+	Version      string
+	Path         string
+	BaseModel    string // 为空时不比较
+	Introduction string // 为空时不比较，沿用远端介绍
+	CoverUrl     string // 封面（本地文件或 URL）
+	Public       *bool  // 为 nil 时不比较
+}
+
+// DesiredModel 配置中声明的模型
+type DesiredModel struct {
+	Name     string
+	Type     string
+	Versions []DesiredVersion
+}
+
+// PlanInput 比较配置与远端模型的输入参数
+type PlanInput struct {
+	ApiKey      string
+	BaseDomain  string
+	Model       DesiredModel
+	NoHashCache bool
+	Context     context.Context
+
+	// OnStatus 阶段状态回调（可选），如开始计算文件签名
+	OnStatus func(message string)
+}
+
+// 版本的变更类型
+const (
+	VersionUnchanged = "unchanged" // 无变化
+	VersionAdd       = "add"       // 新增版本，上传文件
+	VersionReupload  = "reupload"  // 文件签名变化，重新上传文件
+	VersionEdit      = "edit"      // 仅信息变化，不上传文件
+	VersionRemove    = "remove"    // 远端有但配置中没有
+)
+
+// VersionPlan 单个版本的变更计划
+type VersionPlan struct {
+	Version string
+	Action  string
+	Changes []string // 变化说明，用于展示
+
+	Desired *DesiredVersion             // 删除时为 nil
+	Remote  *lib.BizyModelDetailVersion // 新增时为 nil
+
+	IntroChanged     bool
+	PublicChanged    bool
+	BaseModelChanged bool
+	CoverChanged     bool
+}
+
+// ModelPlan 单个模型的变更计划
+type ModelPlan struct {
+	Name     string
+	Type     string
+	ModelId  int64 // 新建时为 0
+	Create   bool
+	Versions []VersionPlan
+	Remote   *lib.BizyModelDetail // 远端模型详情，新建时为 nil
+	Error    error
+}
+
 // DeleteModelResult 删除模型的结果
 type DeleteModelResult struct {
 	Success bool
+	ModelId int64 // 删除部分版本时重新提交得到的模型 ID，未能查询到时为 0
 	Error   error
 }
 
@@ -63,14 +189,32 @@ type UploadInput struct {
 	ModelName  string
 	Versions   []VersionInput
 	Overwrite  bool
+	Update     bool            // 更新已存在的模型：同名版本替换为本次上传的版本，远端其余版本原样一起提交
+	Append     bool            // 向已存在的模型追加版本，已有版本原样一起提交；版本号为空时接着远端已有版本递增
 	Context    context.Context // 用于取消操作
+
+	NoHashCache bool                 // 不使用本地哈希缓存，总是重新计算文件哈希
+	Multipart   lib.MultipartConfig  // 分片上传配置（零值使用默认配置）
+	RateLimiter *lib.RateLimiter     // 上传限速器（nil 不限速），所有并发上传共享
+	Scheduler   *lib.UploadScheduler // 全局上传调度器（nil 时为本次上传创建），可在多个模型间共享
+
+	Uploaded          map[string]*lib.ModelVersion               // 上次已上传文件与封面的版本（按版本号），直接使用其签名与封面地址提交
+	OnVersionUploaded func(index int, version *lib.ModelVersion) // 某个版本的文件与封面上传完成（提交前），可能被并发调用
 }
 
+// 上传进度所处阶段
+const (
+	StageHashing   = "hashing"   // 计算文件哈希
+	StageUploading = "uploading" // 上传文件内容
+)
+
 // UploadProgress 上传进度信息
+// Stage 为 StageHashing 时，Consumed/Total 为当前文件已计算哈希的字节数与文件大小
 type UploadProgress struct {
 	VersionIndex int    // 当前版本索引（0-based）
 	VersionTotal int    // 总版本数
 	FileName     string // 文件名
+	Stage        string // 所处阶段（StageHashing / StageUploading）
 	Consumed     int64  // 已上传字节数
 	Total        int64  // 文件总大小
 }
@@ -82,8 +226,20 @@ type UploadResult struct {
 	TotalCount     int
 	Errors         []error
 	CanceledByUser bool
-	ModelName      string // 模型名称
-	ModelType      string // 模型类型
+	ModelName      string              // 模型名称
+	ModelType      string              // 模型类型
+	Versions       []*lib.ModelVersion // 已提交的版本（含文件签名与封面地址）
+	PendingCommit  bool                // 文件已上传但提交模型失败，已保存待提交记录
+}
+
+// CommitInput 重新提交模型的输入参数
+// 提交内容取自上次上传失败时保存的待提交记录
+type CommitInput struct {
+	ApiKey     string
+	BaseDomain string
+	ModelType  string
+	ModelName  string
+	Context    context.Context // 用于取消操作
 }
 
 // UploadCallback 上传过程的回调接口
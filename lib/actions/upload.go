@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"sync"
 
+	"github.com/cloudwego/hertz/cmd/hz/util/logs"
 	"github.com/siliconflow/bizyair-cli/lib"
 	"github.com/siliconflow/bizyair-cli/meta"
 )
@@ -44,10 +45,38 @@ func ExecuteUpload(input UploadInput, callback UploadCallback) UploadResult {
 	// 3. 创建客户端
 	client := lib.NewClient(input.BaseDomain, input.ApiKey)
 
-	// 4. 检查模型是否存在
-	if !input.Overwrite {
-		exists, err := client.CheckModelExists(input.ModelName, input.ModelType)
+	// 4. 检查模型是否存在（追加版本时模型必须已存在，其已有版本随新版本一起提交）
+	var existing []*lib.ModelVersion
+	if input.Append {
+		versions, remote, err := prepareAppendVersions(ctx, client, input)
 		if err != nil {
+			if errors.Is(err, context.Canceled) {
+				return UploadResult{Success: false, CanceledByUser: true}
+			}
+			return UploadResult{
+				Success: false,
+				Errors:  []error{err},
+			}
+		}
+		input.Versions, existing = versions, remote
+	} else if input.Update {
+		remote, err := fetchExistingVersions(ctx, client, input)
+		if err != nil {
+			if errors.Is(err, context.Canceled) {
+				return UploadResult{Success: false, CanceledByUser: true}
+			}
+			return UploadResult{
+				Success: false,
+				Errors:  []error{err},
+			}
+		}
+		existing = remote
+	} else if !input.Overwrite {
+		exists, err := client.CheckModelExists(ctx, input.ModelName, input.ModelType)
+		if err != nil {
+			if errors.Is(err, context.Canceled) {
+				return UploadResult{Success: false, CanceledByUser: true}
+			}
 			return UploadResult{
 				Success: false,
 				Errors:  []error{lib.WithStep("检查模型", err)},
@@ -64,8 +93,12 @@ func ExecuteUpload(input UploadInput, callback UploadCallback) UploadResult {
 		}
 	}
 
-	// 5. 并发上传所有版本
-	return uploadVersionsConcurrently(ctx, client, input, callback)
+	// 5. 并发上传所有版本（未指定调度器时为本次上传创建一个）
+	if input.Scheduler == nil {
+		input.Scheduler = lib.NewUploadScheduler(meta.UploadParallel)
+		defer input.Scheduler.Close()
+	}
+	return uploadVersionsConcurrently(ctx, client, input, existing, callback)
 }
 
 // validateUploadInput 验证上传参数
@@ -80,6 +113,18 @@ func validateUploadInput(input UploadInput) error {
 		return lib.WithStep("参数验证", fmt.Errorf("模型名称无效: %w", err))
 	}
 
+	if input.Append && input.Overwrite {
+		return lib.WithStep("参数验证", lib.NewValidationError("追加版本与覆盖模型不能同时使用"))
+	}
+	if input.Update && (input.Append || input.Overwrite) {
+		return lib.WithStep("参数验证", lib.NewValidationError("更新模型不能与追加版本或覆盖模型同时使用"))
+	}
+
+	// 验证分片上传配置
+	if err := input.Multipart.Validate(); err != nil {
+		return lib.WithStep("参数验证", err)
+	}
+
 	// 验证版本信息
 	if len(input.Versions) == 0 {
 		return lib.WithStep("参数验证", lib.NewValidationError("至少需要一个版本"))
@@ -96,8 +141,15 @@ func validateUploadInput(input UploadInput) error {
 			return lib.WithStep("参数验证", fmt.Errorf("版本 %d: 路径无效: %w", i+1, err))
 		}
 
+		// 目录上传：目录中至少要有一个可上传的文件
 		if stat.IsDir() {
-			return lib.WithStep("参数验证", lib.NewValidationError(fmt.Sprintf("版本 %d: 不支持目录上传，仅支持文件", i+1)))
+			files, err := lib.CollectUploadFiles(ver.Path)
+			if err != nil {
+				return lib.WithStep("参数验证", fmt.Errorf("版本 %d: %w", i+1, err))
+			}
+			if len(files) == 0 {
+				return lib.WithStep("参数验证", lib.NewValidationError(fmt.Sprintf("版本 %d: 目录中没有可上传的文件", i+1)))
+			}
 		}
 
 		// 验证封面
@@ -112,8 +164,8 @@ func validateUploadInput(input UploadInput) error {
 			}
 		}
 
-		// 验证版本号
-		if ver.Version == "" {
+		// 验证版本号（追加版本时可为空，自动接着远端版本编号）
+		if ver.Version == "" && !input.Append {
 			return lib.WithStep("参数验证", lib.NewValidationError(fmt.Sprintf("版本 %d: 版本号不能为空", i+1)))
 		}
 	}
@@ -121,18 +173,100 @@ func validateUploadInput(input UploadInput) error {
 	return nil
 }
 
+// prepareAppendVersions 查询已存在模型的版本，为新版本分配版本号并检查冲突
+// 同时返回以远端详情重建的已有版本，提交时与新版本一起提交
+func prepareAppendVersions(ctx context.Context, client *lib.Client, input UploadInput) ([]VersionInput, []*lib.ModelVersion, error) {
+	matched, err := FindModelsByName(ctx, input.ApiKey, input.BaseDomain, input.ModelName, input.ModelType)
+	if err != nil {
+		return nil, nil, lib.WithStep("检查模型", err)
+	}
+	if len(matched) == 0 {
+		return nil, nil, lib.WithStep("检查模型", lib.NewValidationError(
+			fmt.Sprintf("模型 '%s'（%s）不存在，无法追加版本", input.ModelName, input.ModelType)))
+	}
+
+	detail, err := getModelDetail(ctx, client, input.ApiKey, input.BaseDomain, matched[0].Id, matched[0])
+	if err != nil {
+		return nil, nil, lib.WithStep("查询模型详情", err)
+	}
+	existing := make([]string, 0, len(detail.Versions))
+	for _, ver := range detail.Versions {
+		existing = append(existing, ver.Version)
+	}
+
+	names := make([]string, len(input.Versions))
+	for i, ver := range input.Versions {
+		names[i] = ver.Version
+	}
+	names = lib.AutoIncrementVersionNames(names, existing)
+
+	// 新版本不能与远端已有版本或彼此重名
+	taken := make(map[string]bool, len(existing)+len(names))
+	for _, name := range existing {
+		taken[name] = true
+	}
+	versions := make([]VersionInput, len(input.Versions))
+	for i, ver := range input.Versions {
+		if taken[names[i]] {
+			return nil, nil, lib.WithStep("检查版本", lib.NewValidationError(
+				fmt.Sprintf("版本 %d: 版本号 '%s' 已存在于模型 '%s'", i+1, names[i], input.ModelName)))
+		}
+		taken[names[i]] = true
+		ver.Version = names[i]
+		versions[i] = ver
+	}
+
+	// 已有版本原样一起提交，在上传前拒绝含目录版本的模型
+	remote := remoteVersions(detail)
+	if err := checkRemoteFiles(remote, nil); err != nil {
+		return nil, nil, lib.WithStep("检查模型", err)
+	}
+	return versions, remote, nil
+}
+
+// fetchExistingVersions 查询已存在模型的版本，以远端详情重建，提交时与本次上传的版本一起提交
+func fetchExistingVersions(ctx context.Context, client *lib.Client, input UploadInput) ([]*lib.ModelVersion, error) {
+	matched, err := FindModelsByName(ctx, input.ApiKey, input.BaseDomain, input.ModelName, input.ModelType)
+	if err != nil {
+		return nil, lib.WithStep("检查模型", err)
+	}
+	if len(matched) == 0 {
+		return nil, lib.WithStep("检查模型", lib.NewValidationError(
+			fmt.Sprintf("模型 '%s'（%s）不存在，无法更新", input.ModelName, input.ModelType)))
+	}
+	detail, err := getModelDetail(ctx, client, input.ApiKey, input.BaseDomain, matched[0].Id, matched[0])
+	if err != nil {
+		return nil, lib.WithStep("查询模型详情", err)
+	}
+
+	// 未重新上传的版本原样一起提交，在上传前拒绝其中的目录版本
+	uploading := make(map[string]bool, len(input.Versions))
+	for _, ver := range input.Versions {
+		if ver.Version != "" {
+			uploading[ver.Version] = true
+		}
+	}
+	remote := remoteVersions(detail)
+	if err := checkRemoteFiles(remote, uploading); err != nil {
+		return nil, lib.WithStep("检查模型", err)
+	}
+	return remote, nil
+}
+
 // uploadVersionsConcurrently 并发上传多个版本
+// existing 为模型已有的版本，提交时与本次上传的版本一起提交（同名版本以本次上传的为准）
 func uploadVersionsConcurrently(
 	ctx context.Context,
 	client *lib.Client,
 	input UploadInput,
+	existing []*lib.ModelVersion,
 	callback UploadCallback,
 ) UploadResult {
 	total := len(input.Versions)
 	versionList := make([]*lib.ModelVersion, total)
 
+	// 所有版本同时开始，实际的上传并发由全局调度器统一控制
 	var wg sync.WaitGroup
-	sem := make(chan struct{}, 3) // 最多3个并发
 	var mu sync.Mutex
 	var uploadErrors []error
 	var canceled bool
@@ -145,19 +279,23 @@ func uploadVersionsConcurrently(
 		go func() {
 			defer wg.Done()
 
-			// 获取信号量
-			sem <- struct{}{}
-			defer func() { <-sem }()
-
 			// 通知开始上传
 			if callback != nil {
 				callback.OnVersionStart(idx, total, filepath.Base(version.Path))
 			}
 
-			// 上传单个版本
-			result := uploadSingleVersion(
-				ctx, client, input.ModelType, version, idx, total, callback,
-			)
+			// 上传单个版本（上次已上传的版本直接复用）
+			var result singleVersionResult
+			if prev, ok := input.Uploaded[version.Version]; ok {
+				result = singleVersionResult{ModelVersion: reuseUploadedVersion(version, prev)}
+			} else {
+				result = uploadSingleVersion(
+					ctx, client, input, version, idx, total, callback,
+				)
+				if result.ModelVersion != nil && input.OnVersionUploaded != nil {
+					input.OnVersionUploaded(idx, result.ModelVersion)
+				}
+			}
 
 			if result.Canceled {
 				mu.Lock()
@@ -217,14 +355,49 @@ func uploadVersionsConcurrently(
 		}
 	}
 
-	// 提交模型
-	_, err := client.CommitModelV2(input.ModelName, input.ModelType, successVersions)
+	// 提交模型（已有版本原样一起提交）
+	commitVersions := successVersions
+	var uploadedNames []string
+	if len(existing) > 0 {
+		commitVersions = mergeVersions(existing, successVersions)
+		for _, mv := range successVersions {
+			uploadedNames = append(uploadedNames, mv.Version)
+		}
+	}
+	_, err := client.CommitModelV2(ctx, input.ModelName, input.ModelType, commitVersions)
 	if err != nil {
+		// 文件均已上传，保存待提交记录，下次可直接提交而无需重新上传
+		pending := &lib.PendingCommit{
+			BaseDomain: input.BaseDomain,
+			ModelName:  input.ModelName,
+			ModelType:  input.ModelType,
+			Versions:   commitVersions,
+			Uploaded:   uploadedNames,
+			LastError:  err.Error(),
+		}
+		saved := true
+		if serr := lib.SavePendingCommit(pending); serr != nil {
+			logs.Warnf("保存待提交记录失败: %v\n", serr)
+			saved = false
+		}
+
+		if errors.Is(err, context.Canceled) {
+			return UploadResult{Success: false, CanceledByUser: true, TotalCount: total, PendingCommit: saved}
+		}
 		return UploadResult{
-			Success: false,
-			Errors:  []error{lib.WithStep("提交模型", err)},
+			Success:       false,
+			SuccessCount:  len(successVersions),
+			TotalCount:    total,
+			Errors:        append(uploadErrors, lib.WithStep("提交模型", err)),
+			ModelName:     input.ModelName,
+			ModelType:     input.ModelType,
+			Versions:      successVersions,
+			PendingCommit: saved,
 		}
 	}
+	if err := lib.DeletePendingCommit(input.BaseDomain, input.ModelType, input.ModelName); err != nil {
+		logs.Warnf("删除待提交记录失败: %v\n", err)
+	}
 
 	return UploadResult{
 		Success:      true,
@@ -233,6 +406,7 @@ func uploadVersionsConcurrently(
 		Errors:       uploadErrors,
 		ModelName:    input.ModelName,
 		ModelType:    input.ModelType,
+		Versions:     successVersions,
 	}
 }
 
@@ -247,7 +421,7 @@ type singleVersionResult struct {
 func uploadSingleVersion(
 	ctx context.Context,
 	client *lib.Client,
-	modelType string,
+	input UploadInput,
 	version VersionInput,
 	index int,
 	total int,
@@ -261,7 +435,7 @@ func uploadSingleVersion(
 		}
 	}
 
-	coverUrl, err := lib.UploadCover(client, version.CoverUrl, ctx, coverStatusCallback)
+	coverUrl, err := lib.UploadCover(ctx, client, version.CoverUrl, coverStatusCallback)
 	if err != nil {
 		if errors.Is(err, context.Canceled) {
 			return singleVersionResult{Canceled: true}
@@ -271,7 +445,7 @@ func uploadSingleVersion(
 		}
 	}
 
-	// 2. 准备文件信息
+	// 2. 上传文件（目录则逐个上传目录中的文件）
 	stat, err := os.Stat(version.Path)
 	if err != nil {
 		return singleVersionResult{
@@ -279,61 +453,193 @@ func uploadSingleVersion(
 		}
 	}
 
-	relPath, _ := filepath.Rel(filepath.Dir(version.Path), version.Path)
+	var sign string
+	var files []*lib.ModelFile
+	if stat.IsDir() {
+		files, err = uploadFolder(ctx, client, input, version.Path, index, total, callback)
+	} else {
+		sign, err = uploadFile(ctx, client, input, version.Path, stat.Size(), index, total, callback)
+	}
+	if err != nil {
+		if errors.Is(err, context.Canceled) {
+			return singleVersionResult{Canceled: true}
+		}
+		return singleVersionResult{
+			Error: lib.WithStep(fmt.Sprintf("版本%d文件上传", index+1), err),
+		}
+	}
+
+	// 4. 构建版本信息
+	var coverUrls []string
+	if coverUrl != "" {
+		coverUrls = []string{coverUrl}
+	}
+
+	modelVersion := &lib.ModelVersion{
+		Version:      version.Version,
+		BaseModel:    version.BaseModel,
+		Introduction: version.Introduction,
+		Public:       version.Public,
+		Sign:         sign,
+		Path:         version.Path,
+		CoverUrls:    coverUrls,
+		Files:        files,
+	}
+
+	return singleVersionResult{ModelVersion: modelVersion}
+}
+
+// reuseUploadedVersion 使用上次上传得到的签名与封面地址构建版本信息，其余信息取自本次输入
+func reuseUploadedVersion(version VersionInput, prev *lib.ModelVersion) *lib.ModelVersion {
+	return &lib.ModelVersion{
+		Version:      version.Version,
+		BaseModel:    version.BaseModel,
+		Introduction: version.Introduction,
+		Public:       version.Public,
+		Sign:         prev.Sign,
+		Path:         version.Path,
+		CoverUrls:    prev.CoverUrls,
+		Files:        prev.Files,
+	}
+}
+
+// uploadFile 上传单个文件，返回文件签名
+func uploadFile(
+	ctx context.Context,
+	client *lib.Client,
+	input UploadInput,
+	path string,
+	size int64,
+	index int,
+	total int,
+	callback UploadCallback,
+) (string, error) {
+	relPath, _ := filepath.Rel(filepath.Dir(path), path)
 	if relPath == "" || relPath == "." {
-		relPath = filepath.Base(version.Path)
+		relPath = filepath.Base(path)
 	}
 
 	file := &lib.FileToUpload{
-		Path:    filepath.ToSlash(version.Path),
+		Path:    filepath.ToSlash(path),
 		RelPath: filepath.ToSlash(relPath),
-		Size:    stat.Size(),
+		Size:    size,
 	}
 
-	// 3. 上传文件（带进度回调）
-	_, err = lib.UnifiedUpload(lib.UploadOptions{
-		File:      file,
-		Client:    client,
-		ModelType: modelType,
-		Context:   ctx,
-		FileIndex: fmt.Sprintf("%d/%d", index+1, total),
+	// 上传文件（带进度回调）
+	_, err := lib.UnifiedUpload(lib.UploadOptions{
+		File:        file,
+		Client:      client,
+		ModelType:   input.ModelType,
+		Context:     ctx,
+		FileIndex:   fmt.Sprintf("%d/%d", index+1, total),
+		NoHashCache: input.NoHashCache,
+		Multipart:   input.Multipart,
+		RateLimiter: input.RateLimiter,
+		Scheduler:   input.Scheduler,
 		ProgressFunc: func(consumed, fileTotal int64) {
 			if callback != nil {
 				callback.OnProgress(UploadProgress{
 					VersionIndex: index,
 					VersionTotal: total,
 					FileName:     filepath.Base(file.RelPath),
+					Stage:        StageUploading,
+					Consumed:     consumed,
+					Total:        fileTotal,
+				})
+			}
+		},
+		HashFunc: func(consumed, fileTotal int64) {
+			if callback != nil {
+				callback.OnProgress(UploadProgress{
+					VersionIndex: index,
+					VersionTotal: total,
+					FileName:     filepath.Base(file.RelPath),
+					Stage:        StageHashing,
 					Consumed:     consumed,
 					Total:        fileTotal,
 				})
 			}
 		},
 	})
+	if err != nil {
+		return "", err
+	}
+	return file.Signature, nil
+}
 
+// uploadFolder 逐个上传目录中的文件，返回保留相对路径的文件列表
+// 进度按整个目录的总字节数汇总
+func uploadFolder(
+	ctx context.Context,
+	client *lib.Client,
+	input UploadInput,
+	root string,
+	index int,
+	total int,
+	callback UploadCallback,
+) ([]*lib.ModelFile, error) {
+	files, err := lib.CollectUploadFiles(root)
 	if err != nil {
-		if errors.Is(err, context.Canceled) {
-			return singleVersionResult{Canceled: true}
-		}
-		return singleVersionResult{
-			Error: lib.WithStep(fmt.Sprintf("版本%d文件上传", index+1), err),
-		}
+		return nil, err
 	}
 
-	// 4. 构建版本信息
-	var coverUrls []string
-	if coverUrl != "" {
-		coverUrls = []string{coverUrl}
+	var folderSize int64
+	for _, file := range files {
+		folderSize += file.Size
 	}
 
-	modelVersion := &lib.ModelVersion{
-		Version:      version.Version,
-		BaseModel:    version.BaseModel,
-		Introduction: version.Introduction,
-		Public:       version.Public,
-		Sign:         file.Signature,
-		Path:         version.Path,
-		CoverUrls:    coverUrls,
+	folderName := filepath.Base(root)
+	modelFiles := make([]*lib.ModelFile, 0, len(files))
+	var doneSize int64
+	for i, file := range files {
+		_, err := lib.UnifiedUpload(lib.UploadOptions{
+			File:        file,
+			Client:      client,
+			ModelType:   input.ModelType,
+			Context:     ctx,
+			FileIndex:   fmt.Sprintf("%d/%d %d/%d", index+1, total, i+1, len(files)),
+			NoHashCache: input.NoHashCache,
+			Multipart:   input.Multipart,
+			RateLimiter: input.RateLimiter,
+			Scheduler:   input.Scheduler,
+			ProgressFunc: func(consumed, _ int64) {
+				if callback != nil {
+					callback.OnProgress(UploadProgress{
+						VersionIndex: index,
+						VersionTotal: total,
+						FileName:     folderName + "/" + file.RelPath,
+						Stage:        StageUploading,
+						Consumed:     doneSize + consumed,
+						Total:        folderSize,
+					})
+				}
+			},
+			HashFunc: func(consumed, fileTotal int64) {
+				if callback != nil {
+					callback.OnProgress(UploadProgress{
+						VersionIndex: index,
+						VersionTotal: total,
+						FileName:     folderName + "/" + file.RelPath,
+						Stage:        StageHashing,
+						Consumed:     consumed,
+						Total:        fileTotal,
+					})
+				}
+			},
+		})
+		if err != nil {
+			if errors.Is(err, context.Canceled) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("%s: %w", file.RelPath, err)
+		}
+		doneSize += file.Size
+
+		modelFiles = append(modelFiles, &lib.ModelFile{
+			Sign: file.Signature,
+			Path: file.RelPath,
+		})
 	}
 
-	return singleVersionResult{ModelVersion: modelVersion}
+	return modelFiles, nil
 }
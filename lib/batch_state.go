@@ -0,0 +1,182 @@
+package lib
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/hertz/cmd/hz/util/logs"
+	"github.com/siliconflow/bizyair-cli/meta"
+)
+
+// 批量上传中模型与版本的状态
+const (
+	BatchPending   = "pending"   // 尚未完成
+	BatchUploaded  = "uploaded"  // 文件与封面已上传，尚未提交（仅用于版本）
+	BatchCommitted = "committed" // 已提交
+	BatchFailed    = "failed"    // 上传或提交失败
+)
+
+// BatchState YAML 批量上传的进度记录，中断后可从上次停止处继续
+type BatchState struct {
+	BaseDomain string        `json:"base_domain"`
+	ConfigPath string        `json:"config_path"` // YAML 配置文件的绝对路径
+	Models     []*BatchModel `json:"models"`
+	CreatedAt  time.Time     `json:"created_at"`
+	UpdatedAt  time.Time     `json:"updated_at"`
+
+	mu sync.Mutex // 多个模型同时上传，更新记录时加锁
+}
+
+// BatchModel 批量上传中单个模型的进度
+type BatchModel struct {
+	Name     string          `json:"name"`
+	Type     string          `json:"type"`
+	Status   string          `json:"status"`
+	Error    string          `json:"error,omitempty"`
+	Versions []*BatchVersion `json:"versions"`
+}
+
+// BatchVersion 批量上传中单个版本的进度
+// 文件与封面上传完成后记录签名与封面地址，续传时无需重新上传即可提交
+type BatchVersion struct {
+	Version   string       `json:"version"`
+	Path      string       `json:"path"`
+	Cover     string       `json:"cover,omitempty"` // 配置中的 cover_path 或 cover_url
+	Status    string       `json:"status"`
+	Sign      string       `json:"sign,omitempty"`
+	Files     []*ModelFile `json:"files,omitempty"`
+	CoverUrls []string     `json:"cover_urls,omitempty"`
+	Error     string       `json:"error,omitempty"`
+}
+
+// NewBatchState 创建新的批量上传进度记录
+func NewBatchState(baseDomain, configPath string) *BatchState {
+	now := time.Now()
+	return &BatchState{BaseDomain: baseDomain, ConfigPath: configPath, CreatedAt: now, UpdatedAt: now}
+}
+
+// getBatchStateFile 按 (域名, 配置文件路径) 生成记录文件路径
+func getBatchStateFile(baseDomain, configPath string) (string, error) {
+	dir, err := GetSfDir(meta.BatchFolder)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(strings.Join([]string{baseDomain, configPath}, "\n")))
+	return filepath.Join(dir, hex.EncodeToString(sum[:])+".json"), nil
+}
+
+// LoadBatchState 读取配置文件的批量上传进度，不存在时返回 nil
+func LoadBatchState(baseDomain, configPath string) (*BatchState, error) {
+	file, err := getBatchStateFile(baseDomain, configPath)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read batch state: %v", err)
+	}
+	var state BatchState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("batch state corrupted: %s: %v", file, err)
+	}
+	return &state, nil
+}
+
+// DeleteBatchState 删除配置文件的批量上传进度
+func DeleteBatchState(baseDomain, configPath string) error {
+	file, err := getBatchStateFile(baseDomain, configPath)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete batch state: %v", err)
+	}
+	return nil
+}
+
+// Update 加锁修改进度并立即保存
+func (s *BatchState) Update(fn func()) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fn()
+	return s.save()
+}
+
+func (s *BatchState) save() error {
+	file, err := getBatchStateFile(s.BaseDomain, s.ConfigPath)
+	if err != nil {
+		return err
+	}
+	s.UpdatedAt = time.Now()
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal batch state: %v", err)
+	}
+
+	tmpFile := file + ".tmp"
+	if err := os.WriteFile(tmpFile, data, 0644); err != nil {
+		return fmt.Errorf("failed to write batch state: %v", err)
+	}
+	if err := os.Rename(tmpFile, file); err != nil {
+		_ = os.Remove(tmpFile)
+		return fmt.Errorf("failed to write batch state: %v", err)
+	}
+	logs.Debugf("batch state saved: %s\n", file)
+	return nil
+}
+
+// Model 按名称和类型查找模型进度，不存在时返回 nil
+func (s *BatchState) Model(name, modelType string) *BatchModel {
+	for _, m := range s.Models {
+		if m.Name == name && m.Type == modelType {
+			return m
+		}
+	}
+	return nil
+}
+
+// Done 所有模型均已提交
+func (s *BatchState) Done() bool {
+	for _, m := range s.Models {
+		if m.Status != BatchCommitted {
+			return false
+		}
+	}
+	return true
+}
+
+// Version 按版本号查找版本进度，不存在时返回 nil
+func (m *BatchModel) Version(name string) *BatchVersion {
+	if m == nil {
+		return nil
+	}
+	for _, v := range m.Versions {
+		if v.Version == name {
+			return v
+		}
+	}
+	return nil
+}
+
+// Committed 模型是否已有提交的版本（即模型已在远端创建）
+func (m *BatchModel) Committed() bool {
+	if m == nil {
+		return false
+	}
+	for _, v := range m.Versions {
+		if v.Status == BatchCommitted {
+			return true
+		}
+	}
+	return false
+}
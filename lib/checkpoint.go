@@ -35,8 +35,8 @@ type CheckpointInfo struct {
 	Expiration      string `json:"expiration,omitempty"` // RFC3339 时间
 }
 
-// GetCheckpointDir 获取checkpoint目录路径 (~/.siliconflow/uploads/)
-func GetCheckpointDir() (string, error) {
+// GetSfDir 获取 ~/.bizyair 下的子目录路径，目录不存在时自动创建
+func GetSfDir(name string) (string, error) {
 	var homeDir string
 	currentOS := runtime.GOOS
 
@@ -50,14 +50,19 @@ func GetCheckpointDir() (string, error) {
 		return "", fmt.Errorf("unable to determine home directory")
 	}
 
-	checkpointDir := filepath.Join(homeDir, meta.SfFolder, meta.CheckpointFolder)
+	dir := filepath.Join(homeDir, meta.SfFolder, name)
 
 	// 确保目录存在
-	if err := os.MkdirAll(checkpointDir, 0770); err != nil {
-		return "", fmt.Errorf("failed to create checkpoint directory: %v", err)
+	if err := os.MkdirAll(dir, 0770); err != nil {
+		return "", fmt.Errorf("failed to create directory %s: %v", dir, err)
 	}
 
-	return checkpointDir, nil
+	return dir, nil
+}
+
+// GetCheckpointDir 获取checkpoint目录路径 (~/.bizyair/uploads/)
+func GetCheckpointDir() (string, error) {
+	return GetSfDir(meta.CheckpointFolder)
 }
 
 // GetCheckpointFile 根据文件签名生成checkpoint文件路径（仅按sha256命名）
@@ -179,9 +184,9 @@ func ValidateCheckpoint(info *CheckpointInfo, file *FileToUpload) bool {
 		return false
 	}
 
-	// 校验分片大小一致
-	if info.PartSize != int64(meta.MultipartPartSize) {
-		logs.Warnf("checkpoint validation failed: part size changed (cp: %d, now: %d)\n", info.PartSize, meta.MultipartPartSize)
+	// 续传沿用 checkpoint 记录的分片大小，只需校验其与文件大小、分片数自洽
+	if info.PartSize <= 0 || (info.FileSize+info.PartSize-1)/info.PartSize != info.TotalParts || info.TotalParts > meta.MultipartMaxParts {
+		logs.Warnf("checkpoint validation failed: invalid part size %d for %d parts\n", info.PartSize, info.TotalParts)
 		return false
 	}
 
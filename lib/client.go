@@ -2,10 +2,9 @@ package lib
 
 import (
 	"bytes"
-	"crypto/tls"
+	"context"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
 	"net/http"
 	"net/url"
 	"reflect"
@@ -22,6 +21,8 @@ import (
 type Client struct {
 	Domain string
 	ApiKey string
+
+	httpConfig HTTPConfig
 }
 
 // Response the response of bizyair
@@ -37,14 +38,15 @@ type Response[T any] struct {
 // NewClient New Client
 func NewClient(domain string, apiKey string) *Client {
 	return &Client{
-		Domain: domain,
-		ApiKey: apiKey,
+		Domain:     domain,
+		ApiKey:     apiKey,
+		httpConfig: getDefaultHTTPConfig(),
 	}
 }
 
-func (c *Client) UserInfo() (*Response[UserInfo], error) {
+func (c *Client) UserInfo(ctx context.Context) (*Response[UserInfo], error) {
 	serverUrl := fmt.Sprintf("%s/%s/user/info", c.Domain, meta.APIv1)
-	body, statusCode, err := c.doGet(serverUrl, nil, c.authHeader())
+	body, statusCode, err := c.doGet(ctx, serverUrl, nil, c.authHeader())
 	if err != nil {
 		return nil, cli.Exit(err, meta.ServerError)
 	}
@@ -55,12 +57,12 @@ func (c *Client) UserInfo() (*Response[UserInfo], error) {
 	return handleResponse[UserInfo](body)
 }
 
-func (c *Client) OssSign(signature string, modelType string) (*Response[FilesResp], error) {
+func (c *Client) OssSign(ctx context.Context, signature string, modelType string) (*Response[FilesResp], error) {
 
 	// if file exists, return file.id, else return oss certificate
 	// serverUrl := fmt.Sprintf("%s:%s/%s/sign?%s=%s&type=%s", c.Host, c.Port, meta.BizyUrl, meta.SignMethod, signature, modelType)
 	serverUrl := fmt.Sprintf("%s/x/%s/files/%s", c.Domain, meta.APIv1, signature)
-	body, statusCode, err := c.doGet(serverUrl, OssSignReq{Type: modelType}, c.authHeader())
+	body, statusCode, err := c.doGet(ctx, serverUrl, OssSignReq{Type: modelType}, c.authHeader())
 	if err != nil {
 		return nil, cli.Exit(err, meta.ServerError)
 	}
@@ -71,9 +73,10 @@ func (c *Client) OssSign(signature string, modelType string) (*Response[FilesRes
 
 }
 
-func (c *Client) CommitFileV2(signature string, objectKey string, md5_hash string, modelType string) (*Response[FilesResp], error) {
+func (c *Client) CommitFileV2(ctx context.Context, signature string, objectKey string, md5_hash string, modelType string) (*Response[FilesResp], error) {
 	serverUrl := fmt.Sprintf("%s/x/%s/files", c.Domain, meta.APIv1)
-	body, statusCode, err := c.doPost(serverUrl, FileCommitReqV2{
+	// 同一签名重复提交结果相同，可安全重试，避免传输完成后因偶发 5xx 失败
+	body, statusCode, err := c.doPostIdempotent(ctx, serverUrl, FileCommitReqV2{
 		Sign:      signature,
 		ObjectKey: objectKey,
 		Md5Hash:   md5_hash,
@@ -89,9 +92,9 @@ func (c *Client) CommitFileV2(signature string, objectKey string, md5_hash strin
 	return handleResponse[FilesResp](body)
 }
 
-func (c *Client) CommitModelV2(modelName string, modelType string, modelVersion []*ModelVersion) (*Response[ModelCommitResp], error) {
+func (c *Client) CommitModelV2(ctx context.Context, modelName string, modelType string, modelVersion []*ModelVersion) (*Response[ModelCommitResp], error) {
 	serverUrl := fmt.Sprintf("%s/x/%s/bizy_models", c.Domain, meta.APIv1)
-	body, statusCode, err := c.doPost(serverUrl, ModelCommitReqV2{
+	body, statusCode, err := c.doPost(ctx, serverUrl, ModelCommitReqV2{
 		Name:     modelName,
 		Type:     modelType,
 		Versions: modelVersion,
@@ -106,7 +109,7 @@ func (c *Client) CommitModelV2(modelName string, modelType string, modelVersion
 	return handleResponse[ModelCommitResp](body)
 }
 
-func (c *Client) ListModel(current int, pageSize int, keyword string, sort string, modelTypes []string, baseModels []string) (*Response[BizyModelListResp], error) {
+func (c *Client) ListModel(ctx context.Context, current int, pageSize int, keyword string, sort string, modelTypes []string, baseModels []string) (*Response[BizyModelListResp], error) {
 	// mode 固定为 "my"（我发布的模型）
 	serverUrl := fmt.Sprintf("%s/x/%s/bizy_models/my", c.Domain, meta.APIv1)
 	param := BizyModelListReq{
@@ -117,7 +120,7 @@ func (c *Client) ListModel(current int, pageSize int, keyword string, sort strin
 		ModelTypes: modelTypes,
 		BaseModels: baseModels,
 	}
-	body, statusCode, err := c.doGet(serverUrl, param, c.authHeader())
+	body, statusCode, err := c.doGet(ctx, serverUrl, param, c.authHeader())
 	if err != nil {
 		return nil, cli.Exit(err, meta.ServerError)
 	}
@@ -128,7 +131,7 @@ func (c *Client) ListModel(current int, pageSize int, keyword string, sort strin
 	return handleResponse[BizyModelListResp](body)
 }
 
-func (c *Client) ListModelFiles(modelType string, modelName string, extName string, public bool) (*Response[ModelListFilesResp], error) {
+func (c *Client) ListModelFiles(ctx context.Context, modelType string, modelName string, extName string, public bool) (*Response[ModelListFilesResp], error) {
 	serverUrl := fmt.Sprintf("%s/x/%s/models/files", c.Domain, meta.APIv1)
 	param := ModelListFilesReq{
 		Type:    modelType,
@@ -136,7 +139,7 @@ func (c *Client) ListModelFiles(modelType string, modelName string, extName stri
 		ExtName: extName,
 		Public:  public,
 	}
-	body, statusCode, err := c.doGet(serverUrl, param, c.authHeader())
+	body, statusCode, err := c.doGet(ctx, serverUrl, param, c.authHeader())
 	if err != nil {
 		return nil, cli.Exit(err, meta.ServerError)
 	}
@@ -148,9 +151,9 @@ func (c *Client) ListModelFiles(modelType string, modelName string, extName stri
 }
 
 // GetBizyModelDetail 根据 bizy_model_id 获取模型详情
-func (c *Client) GetBizyModelDetail(bizyModelId int64) (*Response[BizyModelDetail], error) {
+func (c *Client) GetBizyModelDetail(ctx context.Context, bizyModelId int64) (*Response[BizyModelDetail], error) {
 	serverUrl := fmt.Sprintf("%s/x/%s/bizy_models/%d/detail", c.Domain, meta.APIv1, bizyModelId)
-	body, statusCode, err := c.doGet(serverUrl, nil, c.authHeader())
+	body, statusCode, err := c.doGet(ctx, serverUrl, nil, c.authHeader())
 	if err != nil {
 		return nil, cli.Exit(err, meta.ServerError)
 	}
@@ -160,9 +163,9 @@ func (c *Client) GetBizyModelDetail(bizyModelId int64) (*Response[BizyModelDetai
 	return handleResponse[BizyModelDetail](body)
 }
 
-func (c *Client) RemoveModel(modelType string, modelName string) (*Response[ModelDeleteResp], error) {
+func (c *Client) RemoveModel(ctx context.Context, modelType string, modelName string) (*Response[ModelDeleteResp], error) {
 	serverUrl := fmt.Sprintf("%s/x/%s/models", c.Domain, meta.APIv1)
-	body, statusCode, err := c.doDelete(serverUrl, ModelDeleteReq{
+	body, statusCode, err := c.doDelete(ctx, serverUrl, ModelDeleteReq{
 		Name: modelName,
 		Type: modelType,
 	}, c.authHeader())
@@ -177,9 +180,9 @@ func (c *Client) RemoveModel(modelType string, modelName string) (*Response[Mode
 }
 
 // DeleteBizyModelById 通过 bizy_model_id 删除模型
-func (c *Client) DeleteBizyModelById(bizyModelId int64) (*Response[interface{}], error) {
+func (c *Client) DeleteBizyModelById(ctx context.Context, bizyModelId int64) (*Response[interface{}], error) {
 	serverUrl := fmt.Sprintf("%s/x/%s/bizy_models/%d", c.Domain, meta.APIv1, bizyModelId)
-	body, statusCode, err := c.doDelete(serverUrl, nil, c.authHeader())
+	body, statusCode, err := c.doDelete(ctx, serverUrl, nil, c.authHeader())
 	if err != nil {
 		return nil, cli.Exit(err, meta.ServerError)
 	}
@@ -189,9 +192,9 @@ func (c *Client) DeleteBizyModelById(bizyModelId int64) (*Response[interface{}],
 	return handleResponse[interface{}](body)
 }
 
-func (c *Client) CheckModel(modelType string, modelName string) (*Response[CheckModelResp], error) {
+func (c *Client) CheckModel(ctx context.Context, modelType string, modelName string) (*Response[CheckModelResp], error) {
 	serverUrl := fmt.Sprintf("%s/x/%s/models/check", c.Domain, meta.APIv1)
-	body, statusCode, err := c.doGet(serverUrl, ModelQueryReq{
+	body, statusCode, err := c.doGet(ctx, serverUrl, ModelQueryReq{
 		Name: modelName,
 		Type: modelType,
 	}, c.authHeader())
@@ -206,9 +209,9 @@ func (c *Client) CheckModel(modelType string, modelName string) (*Response[Check
 }
 
 // GetUploadToken 获取临时上传凭证（inputs）
-func (c *Client) GetUploadToken(fileName, fileType string) (*Response[FilesResp], error) {
+func (c *Client) GetUploadToken(ctx context.Context, fileName, fileType string) (*Response[FilesResp], error) {
 	serverUrl := fmt.Sprintf("%s/x/%s/upload/token", c.Domain, meta.APIv1)
-	body, statusCode, err := c.doGet(serverUrl, UploadTokenReq{FileName: fileName, FileType: fileType}, c.authHeader())
+	body, statusCode, err := c.doGet(ctx, serverUrl, UploadTokenReq{FileName: fileName, FileType: fileType}, c.authHeader())
 	if err != nil {
 		return nil, cli.Exit(err, meta.ServerError)
 	}
@@ -219,9 +222,9 @@ func (c *Client) GetUploadToken(fileName, fileType string) (*Response[FilesResp]
 }
 
 // GetCLIUploadToken 获取 CLI 专用上传 token
-func (c *Client) GetCLIUploadToken(fileName string) (*Response[FilesResp], error) {
+func (c *Client) GetCLIUploadToken(ctx context.Context, fileName string) (*Response[FilesResp], error) {
 	serverUrl := fmt.Sprintf("%s/x/%s/upload/token", c.Domain, meta.APIv1)
-	body, statusCode, err := c.doGet(serverUrl, CLIUploadTokenReq{
+	body, statusCode, err := c.doGet(ctx, serverUrl, CLIUploadTokenReq{
 		FileName:      fileName,
 		FileType:      "cli",
 		IgnoreDate:    true,
@@ -237,9 +240,9 @@ func (c *Client) GetCLIUploadToken(fileName string) (*Response[FilesResp], error
 }
 
 // CommitInputResource 提交输入资源，返回可用 url
-func (c *Client) CommitInputResource(name, objectKey string) (*Response[InputResourceCommitResp], error) {
+func (c *Client) CommitInputResource(ctx context.Context, name, objectKey string) (*Response[InputResourceCommitResp], error) {
 	serverUrl := fmt.Sprintf("%s/x/%s/input_resource/commit", c.Domain, meta.APIv1)
-	body, statusCode, err := c.doPost(serverUrl, InputResourceCommitReq{Name: name, ObjectKey: objectKey}, c.authHeader())
+	body, statusCode, err := c.doPost(ctx, serverUrl, InputResourceCommitReq{Name: name, ObjectKey: objectKey}, c.authHeader())
 	if err != nil {
 		return nil, cli.Exit(err, meta.ServerError)
 	}
@@ -251,9 +254,9 @@ func (c *Client) CommitInputResource(name, objectKey string) (*Response[InputRes
 
 // CheckModelExists 检查模型名是否已存在
 // 返回 true 表示模型名已存在（HTTP 200），false 表示不存在（HTTP 404）
-func (c *Client) CheckModelExists(modelName string, modelType string) (bool, error) {
+func (c *Client) CheckModelExists(ctx context.Context, modelName string, modelType string) (bool, error) {
 	serverUrl := fmt.Sprintf("%s/x/%s/bizy_models/exists", c.Domain, meta.APIv1)
-	body, statusCode, err := c.doGet(serverUrl, ModelQueryReq{
+	body, statusCode, err := c.doGet(ctx, serverUrl, ModelQueryReq{
 		Name: modelName,
 		Type: modelType,
 	}, c.authHeader())
@@ -276,10 +279,10 @@ func (c *Client) CheckModelExists(modelName string, modelType string) (bool, err
 }
 
 // GetBaseModelTypes 获取基础模型类型列表
-func (c *Client) GetBaseModelTypes() (*Response[[]*BaseModelTypeItem], error) {
+func (c *Client) GetBaseModelTypes(ctx context.Context) (*Response[[]*BaseModelTypeItem], error) {
 	// 使用固定的社区API地址
 	serverUrl := "https://bizyair.cn/api/special/community/base_model_types"
-	body, statusCode, err := c.doGet(serverUrl, nil, nil)
+	body, statusCode, err := c.doGet(ctx, serverUrl, nil, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -298,12 +301,7 @@ func (c *Client) authHeader() map[string]string {
 }
 
 // doGet do get request
-func (c *Client) doGet(urlStr string, queryParams interface{}, header map[string]string) ([]byte, int, error) {
-	tr := &http.Transport{
-		TLSClientConfig: &tls.Config{},
-	}
-	client := &http.Client{Transport: tr}
-
+func (c *Client) doGet(ctx context.Context, urlStr string, queryParams interface{}, header map[string]string) ([]byte, int, error) {
 	parsedURL, err := url.Parse(urlStr)
 	if err != nil {
 		return nil, -1, err
@@ -340,98 +338,80 @@ func (c *Client) doGet(urlStr string, queryParams interface{}, header map[string
 		parsedURL.RawQuery = query.Encode()
 	}
 
-	req, err := http.NewRequest(meta.HTTPGet, parsedURL.String(), nil)
-	if err != nil {
-		return nil, -1, err
-	}
-
-	if len(header) > 0 {
-		for key, value := range header {
-			req.Header.Set(key, value)
+	// GET 请求幂等，失败时按策略重试
+	return c.doRequest(ctx, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, meta.HTTPGet, parsedURL.String(), nil)
+		if err != nil {
+			return nil, err
 		}
-	}
-	req.Header.Set(meta.HeaderSiliconCliVersion, meta.Version)
 
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, -1, err
-	}
-	defer resp.Body.Close()
-
-	body, err := ioutil.ReadAll(resp.Body)
-	return body, resp.StatusCode, err
+		if len(header) > 0 {
+			for key, value := range header {
+				req.Header.Set(key, value)
+			}
+		}
+		req.Header.Set(meta.HeaderSiliconCliVersion, meta.Version)
+		return req, nil
+	}, true)
 }
 
 // doPost do post request
-func (c *Client) doPost(url string, data interface{}, header map[string]string) ([]byte, int, error) {
-	tr := &http.Transport{
-		TLSClientConfig: &tls.Config{},
-	}
-	client := &http.Client{Transport: tr}
+// POST 默认不重试；确认幂等的接口使用 doPostIdempotent
+func (c *Client) doPost(ctx context.Context, url string, data interface{}, header map[string]string) ([]byte, int, error) {
+	return c.post(ctx, url, data, header, false)
+}
 
+// doPostIdempotent 幂等的 post 请求，失败时按策略重试
+func (c *Client) doPostIdempotent(ctx context.Context, url string, data interface{}, header map[string]string) ([]byte, int, error) {
+	return c.post(ctx, url, data, header, true)
+}
+
+func (c *Client) post(ctx context.Context, url string, data interface{}, header map[string]string, idempotent bool) ([]byte, int, error) {
 	// 将数据编码为JSON
 	jsonData, err := json.Marshal(data)
 	if err != nil {
 		return nil, -1, err
 	}
 
-	req, err := http.NewRequest(meta.HTTPPost, url, bytes.NewBuffer(jsonData))
-	if err != nil {
-		return nil, -1, err
-	}
-
-	if len(header) > 0 {
-		for key, value := range header {
-			req.Header.Set(key, value)
+	return c.doRequest(ctx, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, meta.HTTPPost, url, bytes.NewReader(jsonData))
+		if err != nil {
+			return nil, err
 		}
-	}
-	req.Header.Set(meta.HeaderSiliconCliVersion, meta.Version)
-	req.Header.Set(meta.HeaderContentType, meta.JsonContentType)
-
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, -1, err
-	}
-	defer resp.Body.Close()
 
-	// 读取响应体
-	body, err := ioutil.ReadAll(resp.Body)
-	return body, resp.StatusCode, err
+		if len(header) > 0 {
+			for key, value := range header {
+				req.Header.Set(key, value)
+			}
+		}
+		req.Header.Set(meta.HeaderSiliconCliVersion, meta.Version)
+		req.Header.Set(meta.HeaderContentType, meta.JsonContentType)
+		return req, nil
+	}, idempotent)
 }
 
 // doDelete do delete request
-func (c *Client) doDelete(url string, data interface{}, header map[string]string) ([]byte, int, error) {
-	tr := &http.Transport{
-		TLSClientConfig: &tls.Config{},
-	}
-	client := &http.Client{Transport: tr}
-
+func (c *Client) doDelete(ctx context.Context, url string, data interface{}, header map[string]string) ([]byte, int, error) {
 	jsonData, err := json.Marshal(data)
 	if err != nil {
 		return nil, -1, err
 	}
 
-	req, err := http.NewRequest(meta.HTTPDelete, url, bytes.NewBuffer(jsonData))
-	if err != nil {
-		return nil, -1, err
-	}
-
-	if len(header) > 0 {
-		for key, value := range header {
-			req.Header.Set(key, value)
+	// DELETE 请求幂等，失败时按策略重试
+	return c.doRequest(ctx, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, meta.HTTPDelete, url, bytes.NewReader(jsonData))
+		if err != nil {
+			return nil, err
 		}
-	}
-	req.Header.Set(meta.HeaderContentType, meta.JsonContentType)
-
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, -1, err
-	}
-	defer resp.Body.Close()
 
-	// 读取响应体
-	body, err := ioutil.ReadAll(resp.Body)
-	return body, resp.StatusCode, err
+		if len(header) > 0 {
+			for key, value := range header {
+				req.Header.Set(key, value)
+			}
+		}
+		req.Header.Set(meta.HeaderContentType, meta.JsonContentType)
+		return req, nil
+	}, true)
 }
 
 func handleError(responseBody []byte, statusCode int) error {
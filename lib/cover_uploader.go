@@ -10,7 +10,7 @@ import (
 // UploadCover 统一封面上传逻辑（支持 URL 和本地文件）
 // 返回上传后的 OSS URL
 // statusCallback: 可选的状态回调函数，用于通知封面处理状态
-func UploadCover(client *Client, coverInput string, ctx context.Context, statusCallback func(status, message string)) (string, error) {
+func UploadCover(ctx context.Context, client *Client, coverInput string, statusCallback func(status, message string)) (string, error) {
 	if coverInput == "" {
 		return "", nil
 	}
@@ -30,9 +30,9 @@ func UploadCover(client *Client, coverInput string, ctx context.Context, statusC
 
 	// 1. 如果是 HTTP URL，下载到临时文件
 	if IsHTTPURL(coverInput) {
-		p, cfn, err := DownloadToTemp(coverInput)
+		p, cfn, err := DownloadToTemp(ctx, coverInput)
 		if err != nil {
-			return "", WithStep("封面下载", fmt.Errorf("下载失败: %s, %v", coverInput, err))
+			return "", WithStep("封面下载", fmt.Errorf("下载失败: %s, %w", coverInput, err))
 		}
 		localPath = p
 		cleanup = cfn
@@ -78,9 +78,9 @@ func UploadCover(client *Client, coverInput string, ctx context.Context, statusC
 	}
 
 	// 3. 获取上传凭证
-	token, err := client.GetUploadToken(uploadFileName, "inputs")
+	token, err := client.GetUploadToken(ctx, uploadFileName, "inputs")
 	if err != nil {
-		return "", WithStep("封面凭证", fmt.Errorf("获取上传凭证失败: %s, %v", coverInput, err))
+		return "", WithStep("封面凭证", fmt.Errorf("获取上传凭证失败: %s, %w", coverInput, err))
 	}
 
 	fileRec := token.Data.File
@@ -90,7 +90,7 @@ func UploadCover(client *Client, coverInput string, ctx context.Context, statusC
 	ossCli, err := NewAliOssStorageClient(storage.Endpoint, storage.Bucket,
 		fileRec.AccessKeyId, fileRec.AccessKeySecret, fileRec.SecurityToken)
 	if err != nil {
-		return "", WithStep("封面OSS客户端", fmt.Errorf("创建 OSS 客户端失败: %s, %v", coverInput, err))
+		return "", WithStep("封面OSS客户端", fmt.Errorf("创建 OSS 客户端失败: %s, %w", coverInput, err))
 	}
 
 	coverFile := &FileToUpload{
@@ -101,13 +101,13 @@ func UploadCover(client *Client, coverInput string, ctx context.Context, statusC
 
 	_, err = ossCli.UploadFileCtx(ctx, coverFile, fileRec.ObjectKey, "", nil)
 	if err != nil {
-		return "", WithStep("封面上传", fmt.Errorf("上传 OSS 失败: %s, %v", coverInput, err))
+		return "", WithStep("封面上传", fmt.Errorf("上传 OSS 失败: %s, %w", coverInput, err))
 	}
 
 	// 5. 提交并获取可用 URL
-	commit, err := client.CommitInputResource(uploadFileName, fileRec.ObjectKey)
+	commit, err := client.CommitInputResource(ctx, uploadFileName, fileRec.ObjectKey)
 	if err != nil {
-		return "", WithStep("封面提交", fmt.Errorf("提交失败: %s, %v", coverInput, err))
+		return "", WithStep("封面提交", fmt.Errorf("提交失败: %s, %w", coverInput, err))
 	}
 
 	if commit == nil || commit.Data.Url == "" {
@@ -2,13 +2,18 @@ package lib
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/cloudwego/hertz/cmd/hz/util/logs"
+	"github.com/siliconflow/bizyair-cli/meta"
 )
 
 // DownloadFileOptions 下载文件的选项
@@ -17,90 +22,477 @@ type DownloadFileOptions struct {
 	DestPath     string
 	ProgressFunc func(downloaded, total int64)
 	Context      context.Context
+	Parallel     int // 大文件并行下载的分块请求数，<= 1 时顺序下载
 }
 
 // DownloadFile 下载文件到指定路径
+// 数据先写入 DestPath.part，完成后原子重命名为 DestPath，中断（包括取消）时保留 .part，再次调用从中断处继续：
+//   - 服务端文件的 ETag（或 Last-Modified）记录在 DestPath.part.json，续传时通过 If-Range 校验，文件已变化时从头下载
+//   - 顺序下载通过 HTTP Range 从 .part 末尾续传，服务端不支持 Range 或未返回 ETag/Last-Modified 时从头下载
+//   - Parallel > 1 且文件超过 DownloadParallelMinSize 时按分块并行下载，已完成的分块同样记录在 DestPath.part.json
 func DownloadFile(opts DownloadFileOptions) error {
 	ctx := opts.Context
 	if ctx == nil {
 		ctx = context.Background()
 	}
+	partPath := opts.DestPath + meta.DownloadPartSuffix
+	statePath := opts.DestPath + meta.DownloadStateSuffix
+	client := newDownloadClient()
 
-	// 创建 HTTP 请求
-	req, err := http.NewRequestWithContext(ctx, "GET", opts.URL, nil)
+	state := readDownloadState(statePath)
+	chunked := state != nil && state.ChunkSize > 0
+	if chunked && opts.Parallel <= 1 {
+		// 并行下载留下的 .part 中间有空洞，无法顺序续传
+		removeDownloadParts(opts.DestPath)
+		chunked = false
+	}
+
+	if opts.Parallel > 1 {
+		_, partErr := os.Stat(partPath)
+		// 顺序下载留下的 .part 继续顺序续传
+		if os.IsNotExist(partErr) || chunked {
+			size, validator, err := probeRangeSize(ctx, client, opts.URL)
+			if err != nil {
+				return err
+			}
+			if size >= meta.DownloadParallelMinSize {
+				return downloadParallel(ctx, client, opts, size, validator)
+			}
+			if chunked {
+				removeDownloadParts(opts.DestPath)
+			}
+		}
+	}
+
+	return downloadSequential(ctx, client, opts, true)
+}
+
+// newDownloadClient 大文件下载不设整体超时，由 ctx 控制取消
+func newDownloadClient() *http.Client {
+	return &http.Client{
+		Transport: &http.Transport{
+			Proxy:                 http.ProxyFromEnvironment,
+			ResponseHeaderTimeout: meta.HTTPTimeout,
+		},
+	}
+}
+
+// removeDownloadParts 删除未完成的下载文件及分块进度记录
+func removeDownloadParts(destPath string) {
+	os.Remove(destPath + meta.DownloadPartSuffix)
+	os.Remove(destPath + meta.DownloadStateSuffix)
+}
+
+// downloadSequential 单个请求顺序下载，已有 .part 且记录了服务端文件的校验值时从末尾续传
+func downloadSequential(ctx context.Context, client *http.Client, opts DownloadFileOptions, allowRestart bool) error {
+	partPath := opts.DestPath + meta.DownloadPartSuffix
+	statePath := opts.DestPath + meta.DownloadStateSuffix
+	var offset int64
+	var validator string
+	if st, err := os.Stat(partPath); err == nil {
+		offset = st.Size()
+	}
+	if state := readDownloadState(statePath); state != nil && state.ChunkSize == 0 {
+		validator = state.Validator
+	}
+	if validator == "" {
+		// 无法确认 .part 与服务端文件一致，从头下载
+		offset = 0
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, opts.URL, nil)
 	if err != nil {
 		return fmt.Errorf("failed to create request: %v", err)
 	}
-
-	// 发送请求
-	client := &http.Client{
-		Timeout: 30 * time.Minute, // 大文件下载，设置较长超时
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+		// 服务端文件已变化时返回 200 与完整内容
+		req.Header.Set("If-Range", validator)
 	}
+
 	resp, err := client.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to download: %v", err)
+		return fmt.Errorf("failed to download: %w", err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		if !strings.HasPrefix(resp.Header.Get("Content-Range"), fmt.Sprintf("bytes %d-", offset)) {
+			return fmt.Errorf("unexpected Content-Range: %s", resp.Header.Get("Content-Range"))
+		}
+		if v := responseValidator(resp); v != "" && v != validator {
+			// 服务端忽略了 If-Range 且文件已变化
+			if !allowRestart {
+				return fmt.Errorf("remote file changed during download")
+			}
+			resp.Body.Close()
+			removeDownloadParts(opts.DestPath)
+			return downloadSequential(ctx, client, opts, false)
+		}
+	case http.StatusOK:
+		// 服务端忽略了 Range 或文件已变化，从头下载
+		offset = 0
+	case http.StatusRequestedRangeNotSatisfiable:
+		// .part 已完整或与服务端文件不一致
+		if offset > 0 && resp.Header.Get("Content-Range") == fmt.Sprintf("bytes */%d", offset) {
+			if v := responseValidator(resp); v == "" || v == validator {
+				os.Remove(statePath)
+				return os.Rename(partPath, opts.DestPath)
+			}
+		}
+		if offset > 0 && allowRestart {
+			resp.Body.Close()
+			if err := os.Remove(partPath); err != nil {
+				return fmt.Errorf("failed to remove %s: %v", partPath, err)
+			}
+			os.Remove(statePath)
+			return downloadSequential(ctx, client, opts, false)
+		}
+		return fmt.Errorf("bad status: %s", resp.Status)
+	default:
 		return fmt.Errorf("bad status: %s", resp.Status)
 	}
 
-	// 创建目标文件
-	out, err := os.Create(opts.DestPath)
+	// 记录本次响应的校验值，供中断后续传；没有校验值时不记录，下次从头下载
+	if offset == 0 {
+		state := &downloadState{Validator: responseValidator(resp)}
+		if state.Validator == "" {
+			os.Remove(statePath)
+		} else if err := state.save(statePath); err != nil {
+			return fmt.Errorf("failed to save download state: %v", err)
+		}
+	}
+
+	flag := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if offset > 0 {
+		flag = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	}
+	out, err := os.OpenFile(partPath, flag, 0644)
 	if err != nil {
 		return fmt.Errorf("failed to create file: %v", err)
 	}
-	defer out.Close()
 
 	// 获取文件总大小
-	totalSize := resp.ContentLength
+	totalSize := int64(-1)
+	if resp.ContentLength >= 0 {
+		totalSize = offset + resp.ContentLength
+	}
 
 	// 使用带进度的 reader
 	var reader io.Reader = resp.Body
+	var progress *downloadProgress
 	if opts.ProgressFunc != nil && totalSize > 0 {
-		reader = &downloadProgressReader{
-			reader:       resp.Body,
-			total:        totalSize,
-			progressFunc: opts.ProgressFunc,
-		}
+		progress = newDownloadProgress(offset, totalSize, opts.ProgressFunc)
+		reader = &downloadProgressReader{reader: resp.Body, progress: progress}
 	}
 
 	// 复制数据
 	written, err := io.Copy(out, reader)
+	if cerr := out.Close(); err == nil {
+		err = cerr
+	}
 	if err != nil {
-		return fmt.Errorf("failed to write file: %v", err)
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	if totalSize >= 0 && offset+written != totalSize {
+		return fmt.Errorf("incomplete download: got %d of %d bytes", offset+written, totalSize)
 	}
 
-	logs.Debugf("downloaded %d bytes to %s", written, opts.DestPath)
+	logs.Debugf("downloaded %d bytes (resumed from %d) to %s", written, offset, opts.DestPath)
 
 	// 确保进度回调显示 100%
-	if opts.ProgressFunc != nil && totalSize > 0 {
-		opts.ProgressFunc(totalSize, totalSize)
+	if progress != nil {
+		progress.finish()
+	}
+
+	os.Remove(statePath)
+	return os.Rename(partPath, opts.DestPath)
+}
+
+// responseValidator 返回响应中可用于 If-Range 的校验值：强 ETag 优先，否则为 Last-Modified
+func responseValidator(resp *http.Response) string {
+	if etag := resp.Header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+		return etag
 	}
+	return resp.Header.Get("Last-Modified")
+}
+
+// probeRangeSize 请求首字节，判断服务端是否支持 Range 并获取文件大小与校验值
+// 不支持 Range 或无法获取大小时返回 0
+func probeRangeSize(ctx context.Context, client *http.Client, url string) (int64, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Range", "bytes=0-0")
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to download: %w", err)
+	}
+	// 不读取响应体直接关闭，服务端忽略 Range 时不会下载整个文件
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusPartialContent {
+		return 0, "", nil
+	}
+	contentRange := resp.Header.Get("Content-Range")
+	idx := strings.LastIndex(contentRange, "/")
+	if idx < 0 {
+		return 0, "", nil
+	}
+	size, err := strconv.ParseInt(contentRange[idx+1:], 10, 64)
+	if err != nil {
+		return 0, "", nil
+	}
+	return size, responseValidator(resp), nil
+}
+
+// downloadState 下载进度记录
+// 顺序下载只记录校验值；并行下载还记录分块大小与各分块是否完成
+type downloadState struct {
+	Validator string `json:"validator,omitempty"` // 服务端文件的 ETag 或 Last-Modified
+	Size      int64  `json:"size,omitempty"`
+	ChunkSize int64  `json:"chunk_size,omitempty"`
+	Done      []bool `json:"done,omitempty"`
+}
+
+// readDownloadState 读取进度记录，不存在或已损坏时返回 nil
+func readDownloadState(statePath string) *downloadState {
+	data, err := os.ReadFile(statePath)
+	if err != nil {
+		return nil
+	}
+	var state downloadState
+	if err := json.Unmarshal(data, &state); err != nil {
+		logs.Debugf("discard download state %s: %v", statePath, err)
+		return nil
+	}
+	return &state
+}
+
+// loadDownloadState 读取并行下载的分块进度，大小、分块或校验值变化时重新开始
+// 服务端未返回校验值时无法确认已下载的分块仍然有效，同样重新开始
+func loadDownloadState(statePath string, size int64, validator string) *downloadState {
+	chunks := int((size + meta.DownloadChunkSize - 1) / meta.DownloadChunkSize)
+	fresh := &downloadState{Validator: validator, Size: size, ChunkSize: meta.DownloadChunkSize, Done: make([]bool, chunks)}
+
+	state := readDownloadState(statePath)
+	if state == nil {
+		return fresh
+	}
+	if validator == "" || state.Validator != validator || state.Size != size || state.ChunkSize != meta.DownloadChunkSize || len(state.Done) != chunks {
+		logs.Debugf("discard download state %s: remote file or chunk size changed", statePath)
+		return fresh
+	}
+	return state
+}
+
+func (s *downloadState) save(statePath string) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(statePath, data, 0644)
+}
+
+// downloadParallel 按分块并行下载，每完成一个分块记录一次进度
+func downloadParallel(ctx context.Context, client *http.Client, opts DownloadFileOptions, size int64, validator string) error {
+	partPath := opts.DestPath + meta.DownloadPartSuffix
+	statePath := opts.DestPath + meta.DownloadStateSuffix
 
+	state := loadDownloadState(statePath, size, validator)
+	if _, err := os.Stat(partPath); err != nil {
+		// .part 已被删除，进度记录作废
+		state.Done = make([]bool, len(state.Done))
+	}
+	var pending []int
+	var downloaded int64
+	for i, done := range state.Done {
+		if done {
+			downloaded += state.chunkLen(i)
+		} else {
+			pending = append(pending, i)
+		}
+	}
+	if len(pending) == len(state.Done) {
+		// 没有可复用的分块，丢弃旧文件
+		os.Remove(partPath)
+	}
+
+	out, err := os.OpenFile(partPath, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %v", err)
+	}
+	if err := out.Truncate(size); err != nil {
+		out.Close()
+		return fmt.Errorf("failed to allocate file: %v", err)
+	}
+	if err := state.save(statePath); err != nil {
+		out.Close()
+		return fmt.Errorf("failed to save download state: %v", err)
+	}
+
+	var progress *downloadProgress
+	if opts.ProgressFunc != nil {
+		progress = newDownloadProgress(downloaded, size, opts.ProgressFunc)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	jobs := make(chan int)
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	workers := opts.Parallel
+	if workers > len(pending) {
+		workers = len(pending)
+	}
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				err := downloadChunk(ctx, client, opts.URL, out, state, i, progress)
+				mu.Lock()
+				if err == nil {
+					state.Done[i] = true
+					err = state.save(statePath)
+				}
+				if err != nil && firstErr == nil {
+					firstErr = err
+					cancel()
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, i := range pending {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if cerr := out.Close(); firstErr == nil {
+		firstErr = cerr
+	}
+	if firstErr == nil {
+		firstErr = ctx.Err()
+	}
+	if firstErr != nil {
+		return firstErr
+	}
+
+	logs.Debugf("downloaded %d bytes in %d chunks to %s", size, len(state.Done), opts.DestPath)
+
+	if progress != nil {
+		progress.finish()
+	}
+	os.Remove(statePath)
+	return os.Rename(partPath, opts.DestPath)
+}
+
+// chunkLen 返回第 i 个分块的长度
+func (s *downloadState) chunkLen(i int) int64 {
+	start := int64(i) * s.ChunkSize
+	if start+s.ChunkSize > s.Size {
+		return s.Size - start
+	}
+	return s.ChunkSize
+}
+
+// downloadChunk 下载单个分块并写入文件对应位置
+func downloadChunk(ctx context.Context, client *http.Client, url string, out *os.File, state *downloadState, i int, progress *downloadProgress) error {
+	start := int64(i) * state.ChunkSize
+	length := state.chunkLen(i)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", start, start+length-1))
+	if state.Validator != "" {
+		req.Header.Set("If-Range", state.Validator)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to download: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK && state.Validator != "" {
+		// If-Range 不匹配，服务端文件已变化；再次下载时按新的校验值重新开始
+		return fmt.Errorf("remote file changed during download")
+	}
+	if resp.StatusCode != http.StatusPartialContent {
+		return fmt.Errorf("bad status: %s", resp.Status)
+	}
+	if v := responseValidator(resp); v != "" && v != state.Validator {
+		return fmt.Errorf("remote file changed during download")
+	}
+	if !strings.HasPrefix(resp.Header.Get("Content-Range"), fmt.Sprintf("bytes %d-%d/", start, start+length-1)) {
+		return fmt.Errorf("unexpected Content-Range: %s", resp.Header.Get("Content-Range"))
+	}
+
+	var reader io.Reader = resp.Body
+	if progress != nil {
+		reader = &downloadProgressReader{reader: resp.Body, progress: progress}
+	}
+	written, err := io.Copy(io.NewOffsetWriter(out, start), io.LimitReader(reader, length))
+	if err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	if written != length {
+		return fmt.Errorf("incomplete chunk %d: got %d of %d bytes", i, written, length)
+	}
 	return nil
 }
 
-// downloadProgressReader 带进度回调的 reader
-type downloadProgressReader struct {
-	reader       io.Reader
+// downloadProgress 下载进度，多个分块并行下载时共享
+type downloadProgress struct {
+	mu           sync.Mutex
 	total        int64
 	downloaded   int64
 	progressFunc func(downloaded, total int64)
 	lastUpdate   time.Time
 }
 
-func (r *downloadProgressReader) Read(p []byte) (n int, err error) {
-	n, err = r.reader.Read(p)
-	r.downloaded += int64(n)
+func newDownloadProgress(downloaded, total int64, progressFunc func(downloaded, total int64)) *downloadProgress {
+	return &downloadProgress{downloaded: downloaded, total: total, progressFunc: progressFunc}
+}
+
+func (p *downloadProgress) add(n int64, force bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.downloaded += n
 
 	// 限制更新频率（每 100ms）
 	now := time.Now()
-	if now.Sub(r.lastUpdate) > 100*time.Millisecond || err == io.EOF {
-		r.progressFunc(r.downloaded, r.total)
-		r.lastUpdate = now
+	if now.Sub(p.lastUpdate) > 100*time.Millisecond || force {
+		p.progressFunc(p.downloaded, p.total)
+		p.lastUpdate = now
 	}
+}
 
+func (p *downloadProgress) finish() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.progressFunc(p.total, p.total)
+}
+
+// downloadProgressReader 带进度回调的 reader
+type downloadProgressReader struct {
+	reader   io.Reader
+	progress *downloadProgress
+}
+
+func (r *downloadProgressReader) Read(p []byte) (n int, err error) {
+	n, err = r.reader.Read(p)
+	r.progress.add(int64(n), err == io.EOF)
 	return n, err
 }
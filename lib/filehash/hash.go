@@ -1,6 +1,7 @@
 package filehash
 
 import (
+	"context"
 	"crypto/md5"
 	"crypto/sha256"
 	"encoding/base64"
@@ -9,33 +10,63 @@ import (
 	"hash/crc64"
 	"io"
 	"os"
+	"time"
 )
 
 // CalculateHash computes a SHA256 signature derived from MD5 + CRC64 of the file,
 // and also returns the base64 MD5 string for server-side verification.
 func CalculateHash(filePath string) (string, string, error) {
-	tabECMA := crc64.MakeTable(crc64.ECMA)
-	hashCRC := crc64.New(tabECMA)
+	return CalculateHashCtx(context.Background(), filePath, nil)
+}
 
+// CalculateHashCtx is like CalculateHash, but reads the file only once (CRC64 and
+// MD5 are fed through an io.MultiWriter), reports progress and stops when ctx is done.
+func CalculateHashCtx(ctx context.Context, filePath string, progress func(consumed, total int64)) (string, string, error) {
 	file, err := os.Open(filePath)
 	if err != nil {
 		return "", "", err
 	}
 	defer file.Close()
 
-	if _, err := io.Copy(hashCRC, file); err != nil {
-		return "", "", err
-	}
-	crc1 := hashCRC.Sum64()
-
-	if _, err := file.Seek(0, 0); err != nil {
+	st, err := file.Stat()
+	if err != nil {
 		return "", "", err
 	}
+	total := st.Size()
 
+	hashCRC := crc64.New(crc64.MakeTable(crc64.ECMA))
 	hashMD5 := md5.New()
-	if _, err := io.Copy(hashMD5, file); err != nil {
-		return "", "", err
+	writer := io.MultiWriter(hashCRC, hashMD5)
+
+	buf := make([]byte, 1024*1024)
+	var consumed int64
+	lastReport := time.Now()
+	for {
+		if err := ctx.Err(); err != nil {
+			return "", "", err
+		}
+
+		n, rerr := file.Read(buf)
+		if n > 0 {
+			writer.Write(buf[:n])
+			consumed += int64(n)
+			if progress != nil && time.Since(lastReport) >= 100*time.Millisecond {
+				progress(consumed, total)
+				lastReport = time.Now()
+			}
+		}
+		if rerr == io.EOF {
+			break
+		}
+		if rerr != nil {
+			return "", "", rerr
+		}
 	}
+	if progress != nil {
+		progress(consumed, total)
+	}
+
+	crc1 := hashCRC.Sum64()
 	md5Str := base64.StdEncoding.EncodeToString(hashMD5.Sum(nil))
 
 	hasher := sha256.New()
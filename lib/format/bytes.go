@@ -1,6 +1,12 @@
 package format
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
 
 // FormatBytes converts a size in bytes to a human-readable string like "1.2 MB".
 func FormatBytes(bytes int64) string {
@@ -15,3 +21,58 @@ func FormatBytes(bytes int64) string {
 	}
 	return fmt.Sprintf("%.1f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
 }
+
+// errBelowOneByte reports a positive value that would truncate to 0, which callers treat as "unset".
+var errBelowOneByte = errors.New("less than 1 byte")
+
+// ParseBytes parses a human-readable size like "64MB", "1.5G", "512k" or "1048576"
+// into bytes. Units are 1024-based; the "B"/"iB" suffix is optional.
+func ParseBytes(s string) (int64, error) {
+	str := strings.ToUpper(strings.TrimSpace(s))
+	if str == "" {
+		return 0, fmt.Errorf("empty size")
+	}
+	str = strings.TrimSuffix(str, "IB")
+	str = strings.TrimSuffix(str, "B")
+
+	multiplier := int64(1)
+	if n := len(str); n > 0 {
+		if idx := strings.IndexByte("KMGTPE", str[n-1]); idx >= 0 {
+			for i := 0; i <= idx; i++ {
+				multiplier *= 1024
+			}
+			str = strings.TrimSpace(str[:n-1])
+		}
+	}
+
+	value, err := strconv.ParseFloat(str, 64)
+	if err != nil || value < 0 || math.IsNaN(value) {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+	// Converting an out-of-range float to int64 silently overflows. float64(MaxInt64)
+	// rounds up to 2^63, so anything at or above it is rejected.
+	if value >= float64(math.MaxInt64)/float64(multiplier) {
+		return 0, fmt.Errorf("size %q is too large", s)
+	}
+	n := int64(value * float64(multiplier))
+	if n == 0 && value > 0 {
+		return 0, fmt.Errorf("size %q is %w", s, errBelowOneByte)
+	}
+	return n, nil
+}
+
+// ParseRate parses a transfer rate like "20MB/s" or "512K" into bytes per second.
+func ParseRate(s string) (int64, error) {
+	str := strings.TrimSpace(s)
+	if lower := strings.ToLower(str); strings.HasSuffix(lower, "/s") {
+		str = str[:len(str)-2]
+	}
+	n, err := ParseBytes(str)
+	if errors.Is(err, errBelowOneByte) {
+		return 0, fmt.Errorf("rate %q is less than 1 B/s, use 0 for no limit", s)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("invalid rate %q", s)
+	}
+	return n, nil
+}
@@ -0,0 +1,198 @@
+package lib
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/hertz/cmd/hz/util/logs"
+	"github.com/siliconflow/bizyair-cli/lib/filehash"
+	"github.com/siliconflow/bizyair-cli/meta"
+)
+
+// HashCacheEntry 文件哈希缓存记录
+// 以 (绝对路径, 大小, 修改时间, inode) 标识文件，任一变化即视为缓存失效
+type HashCacheEntry struct {
+	Path      string    `json:"path"`      // 文件绝对路径
+	Size      int64     `json:"size"`      // 文件大小
+	ModTime   int64     `json:"mod_time"`  // 修改时间（UnixNano）
+	Inode     uint64    `json:"inode"`     // inode（Windows 下为 0）
+	Signature string    `json:"signature"` // 文件SHA256签名
+	Md5       string    `json:"md5"`       // base64 编码的 MD5
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// getHashCacheFile 根据文件绝对路径生成缓存文件路径
+func getHashCacheFile(absPath string) (string, error) {
+	dir, err := GetSfDir(meta.HashCacheFolder)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(absPath))
+	return filepath.Join(dir, hex.EncodeToString(sum[:])+".json"), nil
+}
+
+// newHashCacheEntry 读取文件当前的标识信息
+func newHashCacheEntry(path string) (*HashCacheEntry, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	st, err := os.Stat(absPath)
+	if err != nil {
+		return nil, err
+	}
+	return &HashCacheEntry{
+		Path:    absPath,
+		Size:    st.Size(),
+		ModTime: st.ModTime().UnixNano(),
+		Inode:   fileInode(st),
+	}, nil
+}
+
+// LoadHashCache 查询文件的哈希缓存，文件未变化时返回签名与 MD5
+func LoadHashCache(path string) (string, string, bool) {
+	current, err := newHashCacheEntry(path)
+	if err != nil {
+		return "", "", false
+	}
+	cacheFile, err := getHashCacheFile(current.Path)
+	if err != nil {
+		return "", "", false
+	}
+	data, err := os.ReadFile(cacheFile)
+	if err != nil {
+		return "", "", false
+	}
+
+	var entry HashCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		logs.Debugf("hash cache corrupted, ignore: %s\n", cacheFile)
+		return "", "", false
+	}
+	if entry.Path != current.Path || entry.Size != current.Size ||
+		entry.ModTime != current.ModTime || entry.Inode != current.Inode ||
+		entry.Signature == "" || entry.Md5 == "" {
+		return "", "", false
+	}
+
+	logs.Debugf("hash cache hit: %s\n", current.Path)
+	return entry.Signature, entry.Md5, true
+}
+
+// SaveHashCache 保存文件的哈希缓存
+// before 为计算哈希前读取的文件标识，文件在计算期间被修改时不保存，避免缓存与内容不符的哈希
+func SaveHashCache(before *HashCacheEntry, signature, md5Hash string) error {
+	entry, err := newHashCacheEntry(before.Path)
+	if err != nil {
+		return err
+	}
+	if entry.Size != before.Size || entry.ModTime != before.ModTime || entry.Inode != before.Inode {
+		return fmt.Errorf("file changed while hashing, skip cache: %s", entry.Path)
+	}
+	entry.Signature = signature
+	entry.Md5 = md5Hash
+	entry.CreatedAt = time.Now()
+
+	cacheFile, err := getHashCacheFile(entry.Path)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal hash cache: %v", err)
+	}
+
+	// 先写同目录下的唯一临时文件再重命名，避免并发保存同一文件时互相覆盖或读到半截内容
+	tmp, err := os.CreateTemp(filepath.Dir(cacheFile), filepath.Base(cacheFile)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write hash cache: %v", err)
+	}
+	tmpFile := tmp.Name()
+	_, err = tmp.Write(data)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmpFile, cacheFile)
+	}
+	if err != nil {
+		_ = os.Remove(tmpFile)
+		return fmt.Errorf("failed to write hash cache: %v", err)
+	}
+	return nil
+}
+
+// ClearHashCache 清空哈希缓存，返回删除的记录数
+func ClearHashCache() (int, error) {
+	dir, err := GetSfDir(meta.HashCacheFolder)
+	if err != nil {
+		return 0, err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read hash cache directory: %v", err)
+	}
+
+	count := 0
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		if err := os.Remove(filepath.Join(dir, e.Name())); err != nil {
+			return count, fmt.Errorf("failed to delete hash cache: %v", err)
+		}
+		if strings.HasSuffix(e.Name(), ".json") {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// FileSignature 计算文件签名，useCache 为 true 且文件未变化时直接使用本地缓存
+func FileSignature(ctx context.Context, path string, useCache bool, progress func(consumed, total int64)) (string, error) {
+	var before *HashCacheEntry
+	if useCache {
+		if sign, _, ok := LoadHashCache(path); ok {
+			return sign, nil
+		}
+		before, _ = newHashCacheEntry(path)
+	}
+	sign, md5Hash, err := filehash.CalculateHashCtx(ctx, path, progress)
+	if err != nil {
+		return "", err
+	}
+	if before != nil {
+		if err := SaveHashCache(before, sign, md5Hash); err != nil {
+			logs.Warnf("保存哈希缓存失败: %v\n", err)
+		}
+	}
+	return sign, nil
+}
+
+// PathSignature 计算版本路径的签名：文件返回其签名，目录返回其中每个文件（相对路径）的签名
+func PathSignature(ctx context.Context, path string, useCache bool) (string, []*ModelFile, error) {
+	if !IsDir(path) {
+		sign, err := FileSignature(ctx, path, useCache, nil)
+		return sign, nil, err
+	}
+	files, err := CollectUploadFiles(path)
+	if err != nil {
+		return "", nil, err
+	}
+	modelFiles := make([]*ModelFile, 0, len(files))
+	for _, file := range files {
+		sign, err := FileSignature(ctx, file.Path, useCache, nil)
+		if err != nil {
+			return "", nil, fmt.Errorf("%s: %w", file.RelPath, err)
+		}
+		modelFiles = append(modelFiles, &ModelFile{Sign: sign, Path: file.RelPath})
+	}
+	return "", modelFiles, nil
+}
@@ -0,0 +1,159 @@
+package lib
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/hertz/cmd/hz/util/logs"
+	"github.com/siliconflow/bizyair-cli/meta"
+)
+
+// HTTPConfig API 请求的超时与重试策略
+type HTTPConfig struct {
+	Timeout     time.Duration // 单次请求超时（含读取响应体），0 表示不限制
+	MaxRetries  int           // 幂等请求的最大重试次数（不含首次请求）
+	BaseBackoff time.Duration // 首次重试前的基础等待时间，之后指数增长
+	MaxBackoff  time.Duration // 单次等待的上限（含 Retry-After）
+}
+
+// DefaultHTTPConfig 返回默认的请求策略
+func DefaultHTTPConfig() HTTPConfig {
+	return HTTPConfig{
+		Timeout:     meta.HTTPTimeout,
+		MaxRetries:  meta.HTTPMaxRetries,
+		BaseBackoff: meta.HTTPBaseBackoff,
+		MaxBackoff:  meta.HTTPMaxBackoff,
+	}
+}
+
+var (
+	httpConfigMu      sync.RWMutex
+	defaultHTTPConfig = DefaultHTTPConfig()
+
+	// sharedTransport 所有 Client 共享的连接池，复用 TCP/TLS 连接
+	sharedTransport = &http.Transport{
+		TLSClientConfig:     &tls.Config{},
+		MaxIdleConns:        64,
+		MaxIdleConnsPerHost: 16,
+		IdleConnTimeout:     90 * time.Second,
+		TLSHandshakeTimeout: 10 * time.Second,
+	}
+)
+
+// SetDefaultHTTPConfig 设置之后创建的 Client 使用的请求策略
+func SetDefaultHTTPConfig(cfg HTTPConfig) {
+	httpConfigMu.Lock()
+	defer httpConfigMu.Unlock()
+	defaultHTTPConfig = cfg
+}
+
+func getDefaultHTTPConfig() HTTPConfig {
+	httpConfigMu.RLock()
+	defer httpConfigMu.RUnlock()
+	return defaultHTTPConfig
+}
+
+// doRequest 发送请求并读取响应体
+// idempotent 为 true 时，遇到网络错误、5xx、429 按抖动指数退避重试，并遵循 Retry-After
+// newRequest 每次尝试都会调用，保证请求体可以重新发送
+func (c *Client) doRequest(ctx context.Context, newRequest func() (*http.Request, error), idempotent bool) ([]byte, int, error) {
+	cfg := c.httpConfig
+	client := &http.Client{Transport: sharedTransport, Timeout: cfg.Timeout}
+
+	maxRetries := 0
+	if idempotent {
+		maxRetries = cfg.MaxRetries
+	}
+
+	for attempt := 0; ; attempt++ {
+		req, err := newRequest()
+		if err != nil {
+			return nil, -1, err
+		}
+
+		body, statusCode, retryAfter, err := sendRequest(client, req)
+		if !shouldRetry(statusCode, err) || attempt >= maxRetries {
+			return body, statusCode, err
+		}
+
+		wait := backoff(cfg, attempt, retryAfter)
+		if err != nil {
+			logs.Debugf("%s %s failed: %v, retry %d/%d in %s\n", req.Method, req.URL.Path, err, attempt+1, maxRetries, wait)
+		} else {
+			logs.Debugf("%s %s returned %d, retry %d/%d in %s\n", req.Method, req.URL.Path, statusCode, attempt+1, maxRetries, wait)
+		}
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return nil, -1, ctx.Err()
+		}
+	}
+}
+
+// sendRequest 发送单次请求，返回响应体、状态码与 Retry-After
+func sendRequest(client *http.Client, req *http.Request) ([]byte, int, time.Duration, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, -1, 0, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	return body, resp.StatusCode, parseRetryAfter(resp.Header.Get("Retry-After")), err
+}
+
+// shouldRetry 网络错误、5xx 与 429 可重试
+func shouldRetry(statusCode int, err error) bool {
+	if err != nil {
+		// 网络错误（含超时、连接被重置、读取响应中断）均可重试，主动取消除外
+		return !errors.Is(err, context.Canceled)
+	}
+	return statusCode == http.StatusTooManyRequests || statusCode >= http.StatusInternalServerError
+}
+
+// backoff 计算第 attempt 次重试前的等待时间
+// 服务端返回 Retry-After 时以其为准，否则为带随机抖动的指数退避
+func backoff(cfg HTTPConfig, attempt int, retryAfter time.Duration) time.Duration {
+	wait := retryAfter
+	if wait <= 0 {
+		exp := cfg.BaseBackoff << uint(attempt)
+		if exp <= 0 || exp > cfg.MaxBackoff {
+			exp = cfg.MaxBackoff
+		}
+		// 在 [exp/2, exp) 之间随机，避免多个并发请求同时重试
+		wait = exp/2 + time.Duration(rand.Int63n(int64(exp/2)+1))
+	}
+	if cfg.MaxBackoff > 0 && wait > cfg.MaxBackoff {
+		wait = cfg.MaxBackoff
+	}
+	return wait
+}
+
+// parseRetryAfter 解析 Retry-After（秒数或 HTTP 日期）
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// String 用于 verbose 日志中打印策略
+func (cfg HTTPConfig) String() string {
+	return fmt.Sprintf("timeout=%s retries=%d backoff=%s..%s", cfg.Timeout, cfg.MaxRetries, cfg.BaseBackoff, cfg.MaxBackoff)
+}
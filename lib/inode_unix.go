@@ -0,0 +1,17 @@
+//go:build !windows
+// +build !windows
+
+package lib
+
+import (
+	"os"
+	"syscall"
+)
+
+// fileInode 返回文件的 inode 号
+func fileInode(info os.FileInfo) uint64 {
+	if st, ok := info.Sys().(*syscall.Stat_t); ok {
+		return uint64(st.Ino)
+	}
+	return 0
+}
@@ -0,0 +1,11 @@
+//go:build windows
+// +build windows
+
+package lib
+
+import "os"
+
+// fileInode Windows 下 os.Stat 不提供文件索引号，仅依赖路径、大小与修改时间
+func fileInode(info os.FileInfo) uint64 {
+	return 0
+}
@@ -0,0 +1,57 @@
+package lib
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+)
+
+// NameMatcher 按通配符（glob）或正则表达式匹配模型名
+type NameMatcher struct {
+	glob    string
+	pattern string // 用户输入的正则表达式
+	regex   *regexp.Regexp
+}
+
+// NewNameMatcher 创建名称匹配器，glob 与 regex 只能指定一个
+// glob 语法同 path.Match（* ? [...]），regex 为 Go 正则表达式；两者都需匹配完整名称
+func NewNameMatcher(glob, regex string) (*NameMatcher, error) {
+	if glob != "" && regex != "" {
+		return nil, fmt.Errorf("glob 与 regex 不能同时指定")
+	}
+	if glob == "" && regex == "" {
+		return nil, fmt.Errorf("glob 与 regex 至少指定一个")
+	}
+	if glob != "" {
+		if _, err := path.Match(glob, ""); err != nil {
+			return nil, fmt.Errorf("glob 表达式无效: %s: %w", glob, err)
+		}
+		return &NameMatcher{glob: glob}, nil
+	}
+	if _, err := regexp.Compile(regex); err != nil {
+		return nil, fmt.Errorf("正则表达式无效: %s: %w", regex, err)
+	}
+	// 锚定首尾，避免只匹配名称的一部分而误删
+	re, err := regexp.Compile("^(?:" + regex + ")$")
+	if err != nil {
+		return nil, fmt.Errorf("正则表达式无效: %s: %w", regex, err)
+	}
+	return &NameMatcher{pattern: regex, regex: re}, nil
+}
+
+// Match 判断名称是否匹配
+func (m *NameMatcher) Match(name string) bool {
+	if m.regex != nil {
+		return m.regex.MatchString(name)
+	}
+	ok, _ := path.Match(m.glob, name)
+	return ok
+}
+
+// String 返回匹配表达式，用于提示信息
+func (m *NameMatcher) String() string {
+	if m.regex != nil {
+		return "regex:" + m.pattern
+	}
+	return "glob:" + m.glob
+}
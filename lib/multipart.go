@@ -0,0 +1,64 @@
+package lib
+
+import (
+	"fmt"
+
+	"github.com/siliconflow/bizyair-cli/lib/format"
+	"github.com/siliconflow/bizyair-cli/meta"
+)
+
+// MultipartConfig 分片上传配置
+// 字段为零值时使用 meta 中的默认值
+type MultipartConfig struct {
+	PartSize  int64 // 分片大小（会按文件大小自适应放大，保证不超过最大分片数）
+	Threshold int64 // 文件大小达到该值时使用分片上传
+}
+
+// DefaultMultipartConfig 返回默认的分片上传配置
+func DefaultMultipartConfig() MultipartConfig {
+	return MultipartConfig{
+		PartSize:  meta.MultipartPartSize,
+		Threshold: meta.MultipartThreshold,
+	}
+}
+
+// WithDefaults 用默认值填充未设置的字段
+func (c MultipartConfig) WithDefaults() MultipartConfig {
+	def := DefaultMultipartConfig()
+	if c.PartSize <= 0 {
+		c.PartSize = def.PartSize
+	}
+	if c.Threshold <= 0 {
+		c.Threshold = def.Threshold
+	}
+	return c
+}
+
+// Validate 校验分片配置是否在 OSS 允许的范围内
+func (c MultipartConfig) Validate() error {
+	if c.PartSize != 0 && (c.PartSize < meta.MultipartMinSize || c.PartSize > meta.MultipartMaxSize) {
+		return NewValidationError(fmt.Sprintf("分片大小需在 %s 到 %s 之间",
+			format.FormatBytes(meta.MultipartMinSize), format.FormatBytes(meta.MultipartMaxSize)))
+	}
+	if c.Threshold < 0 {
+		return NewValidationError("分片上传阈值不能为负数")
+	}
+	// 低于阈值的文件用简单上传，OSS 简单上传的单个文件同样不能超过 5GB
+	if c.Threshold > meta.MultipartMaxSize {
+		return NewValidationError(fmt.Sprintf("分片上传阈值不能超过 %s", format.FormatBytes(meta.MultipartMaxSize)))
+	}
+	return nil
+}
+
+// PartSizeFor 计算指定文件实际使用的分片大小
+// 按配置的分片大小切分超过 OSS 最大分片数时，放大分片（按 1MB 对齐）
+func (c MultipartConfig) PartSizeFor(totalSize int64) int64 {
+	partSize := c.WithDefaults().PartSize
+	if (totalSize+partSize-1)/partSize <= meta.MultipartMaxParts {
+		return partSize
+	}
+
+	const align = 1024 * 1024
+	partSize = (totalSize + meta.MultipartMaxParts - 1) / meta.MultipartMaxParts
+	return (partSize + align - 1) / align * align
+}
@@ -1,10 +1,12 @@
 package lib
 
 import (
-	"bytes"
 	"context"
+	"crypto/md5"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"hash"
 	"io"
 	"os"
 	"strings"
@@ -26,6 +28,9 @@ type AliOssStorageClient struct {
 	ossAccessKeyId   string
 	ossAccessKey     string
 	ossExpiration    string
+	multipart        MultipartConfig
+	limiter          *RateLimiter
+	scheduler        *UploadScheduler
 }
 
 type FileToUpload struct {
@@ -71,6 +76,7 @@ func NewAliOssStorageClient(endpoint, bucketName, accessKey, secretKey, security
 		ossAccessKeyId:   accessKey,
 		ossAccessKey:     secretKey,
 		ossExpiration:    "",
+		multipart:        DefaultMultipartConfig(),
 	}
 
 	logs.Debugf("new oss storage client: %v", ossStorageClient)
@@ -82,6 +88,21 @@ func (a *AliOssStorageClient) SetExpiration(exp string) {
 	a.ossExpiration = exp
 }
 
+// SetRateLimiter 设置上传限速器，nil 表示不限速
+func (a *AliOssStorageClient) SetRateLimiter(limiter *RateLimiter) {
+	a.limiter = limiter
+}
+
+// SetScheduler 设置全局上传调度器，分片与简单上传都经由调度器执行
+func (a *AliOssStorageClient) SetScheduler(scheduler *UploadScheduler) {
+	a.scheduler = scheduler
+}
+
+// SetMultipartConfig 设置分片上传配置，未设置的字段使用默认值
+func (a *AliOssStorageClient) SetMultipartConfig(cfg MultipartConfig) {
+	a.multipart = cfg.WithDefaults()
+}
+
 func (a *AliOssStorageClient) UploadFile(file *FileToUpload, objectName string, fileIndex string, progress func(int64, int64)) (string, error) {
 	return a.UploadFileCtx(context.TODO(), file, objectName, fileIndex, progress)
 }
@@ -103,11 +124,11 @@ func (a *AliOssStorageClient) UploadFileCtx(ctx context.Context, file *FileToUpl
 	}
 	defer f.Close()
 
-	// 创建进度追踪 reader
-	var reader io.Reader = f
+	// 创建进度追踪 reader（包在限速 reader 外层，进度按实际发送的字节计算）
+	reader := a.limiter.WrapReader(ctx, f)
 	if progress != nil {
 		reader = &progressReader{
-			reader:      f,
+			reader:      reader,
 			total:       totalSize,
 			progress:    progress,
 			lastTime:    time.Now(),
@@ -122,7 +143,15 @@ func (a *AliOssStorageClient) UploadFileCtx(ctx context.Context, file *FileToUpl
 		Body:   reader,
 	}
 
-	_, err = a.ossClient.PutObject(ctx, putRequest)
+	put := func() error {
+		_, err := a.ossClient.PutObject(ctx, putRequest)
+		return err
+	}
+	if a.scheduler != nil {
+		err = a.scheduler.Do(ctx, file.Path, put)
+	} else {
+		err = put()
+	}
 	if err != nil {
 		return "", fmt.Errorf("failed to put object %v", err)
 	}
@@ -175,8 +204,8 @@ func (a *AliOssStorageClient) UploadFileMultipart(ctx context.Context, file *Fil
 	logs.Debugf("[%s] file size: %d bytes (%.2f MB)\n", fileIndex, totalSize, float64(totalSize)/(1024*1024))
 
 	// 判断是否使用分片上传
-	if totalSize < meta.MultipartThreshold {
-		logs.Debugf("[%s] file size < %d MB, using simple upload\n", fileIndex, meta.MultipartThreshold/(1024*1024))
+	if totalSize < a.multipart.Threshold {
+		logs.Debugf("[%s] file size < %d MB, using simple upload\n", fileIndex, a.multipart.Threshold/(1024*1024))
 		return a.UploadFileCtx(ctx, file, objectName, fileIndex, progress)
 	}
 
@@ -252,15 +281,16 @@ func (a *AliOssStorageClient) UploadFileMultipart(ctx context.Context, file *Fil
 		uploadID = *initResult.UploadId
 		logs.Debugf("[%s] initiated new upload (uploadID: %s)\n", fileIndex, uploadID)
 
-		// 创建新的checkpoint
+		// 创建新的checkpoint（分片大小按文件大小自适应，之后续传沿用记录的分片大小）
+		partSize := a.multipart.PartSizeFor(totalSize)
 		checkpoint = &CheckpointInfo{
 			ObjectKey:       objectName,
 			UploadID:        uploadID,
 			FilePath:        file.Path,
 			FileSize:        totalSize,
 			FileSignature:   file.Signature,
-			PartSize:        meta.MultipartPartSize,
-			TotalParts:      (totalSize + meta.MultipartPartSize - 1) / meta.MultipartPartSize,
+			PartSize:        partSize,
+			TotalParts:      (totalSize + partSize - 1) / partSize,
 			UploadedParts:   []oss.UploadPart{},
 			CreatedAt:       time.Now(),
 			Bucket:          a.ossBucketName,
@@ -364,7 +394,7 @@ func (a *AliOssStorageClient) uploadParts(
 	progress func(int64, int64),
 	checkpointFile string,
 ) ([]oss.UploadPart, error) {
-	partSize := int64(meta.MultipartPartSize)
+	partSize := checkpoint.PartSize
 	partCount := (totalSize + partSize - 1) / partSize
 
 	logs.Debugf("[%s] total parts: %d (part size: %.2f MB)\n", fileIndex, partCount, float64(partSize)/(1024*1024))
@@ -409,8 +439,12 @@ func (a *AliOssStorageClient) uploadParts(
 		}
 	}
 
-	// 使用信号量控制并发数
-	sem := make(chan struct{}, meta.MultipartParallel)
+	// 分片经由全局调度器执行，未设置时使用临时调度器控制并发数
+	scheduler := a.scheduler
+	if scheduler == nil {
+		scheduler = NewUploadScheduler(meta.UploadParallel)
+		defer scheduler.Close()
+	}
 	errChan := make(chan error, partCount)
 	var wg sync.WaitGroup
 	var mu sync.Mutex
@@ -437,21 +471,18 @@ func (a *AliOssStorageClient) uploadParts(
 		go func(partNum int64, off int64, size int64) {
 			defer wg.Done()
 
-			// 获取信号量
-			sem <- struct{}{}
-			defer func() { <-sem }()
-
-			// 检查上下文是否已取消
-			select {
-			case <-ctx.Done():
-				errChan <- ctx.Err()
-				return
-			default:
-			}
-
-			// 上传单个分片（带重试）
-			part, err := a.uploadPartWithRetry(ctx, f, objectKey, uploadID, partNum, off, size, fileIndex, int(partCount))
+			// 上传单个分片（带重试），同一文件的分片属于同一分组，与其他文件轮流执行
+			var part oss.UploadPart
+			err := scheduler.Do(ctx, file.Path, func() error {
+				var perr error
+				part, perr = a.uploadPartWithRetry(ctx, f, objectKey, uploadID, partNum, off, size, fileIndex, int(partCount))
+				return perr
+			})
 			if err != nil {
+				if errors.Is(err, context.Canceled) {
+					errChan <- err
+					return
+				}
 				errChan <- fmt.Errorf("part %d failed: %v", partNum, err)
 				return
 			}
@@ -512,35 +543,50 @@ func (a *AliOssStorageClient) uploadPartWithRetry(
 	maxRetries := 3
 	var lastErr error
 
+	// 分片直接从磁盘流式读取，不整块读入内存；上传的同时计算 MD5，每次尝试只读一遍分片
+	// body 可 Seek，SDK 重试时回绕到开头并重新计算
+	body := &md5SectionReader{section: io.NewSectionReader(file, offset, size), hash: md5.New()}
+
 	for retry := 0; retry <= maxRetries; retry++ {
 		if retry > 0 {
 			logs.Warnf("[%s] retrying part %d/%d (attempt %d/%d)\n", fileIndex, partNumber, totalParts, retry+1, maxRetries+1)
 			time.Sleep(time.Second * time.Duration(retry)) // 指数退避
 		}
 
-		// 读取分片数据
-		buffer := make([]byte, size)
-		_, err := file.ReadAt(buffer, offset)
-		if err != nil && err != io.EOF {
+		if _, err := body.Seek(0, io.SeekStart); err != nil {
 			lastErr = err
 			continue
 		}
 
 		// 上传分片
 		request := &oss.UploadPartRequest{
-			Bucket:     oss.Ptr(a.ossBucketName),
-			Key:        oss.Ptr(objectKey),
-			UploadId:   oss.Ptr(uploadID),
-			PartNumber: int32(partNumber),
-			Body:       bytes.NewReader(buffer),
+			Bucket:        oss.Ptr(a.ossBucketName),
+			Key:           oss.Ptr(objectKey),
+			UploadId:      oss.Ptr(uploadID),
+			PartNumber:    int32(partNumber),
+			ContentLength: oss.Ptr(size),
+			Body:          a.limiter.WrapReader(ctx, body),
 		}
 
 		result, err := a.ossClient.UploadPart(ctx, request)
 		if err != nil {
+			if errors.Is(err, context.Canceled) {
+				return oss.UploadPart{}, err
+			}
 			lastErr = err
 			continue
 		}
 
+		// 分片的 ETag 即 OSS 收到内容的 MD5（十六进制），与读取时计算的 MD5 不一致说明传输过程中数据损坏
+		partMD5, err := body.sum()
+		if err != nil {
+			return oss.UploadPart{}, fmt.Errorf("failed to read part %d: %v", partNumber, err)
+		}
+		if etag := strings.Trim(oss.ToString(result.ETag), "\""); !strings.EqualFold(etag, partMD5) {
+			lastErr = fmt.Errorf("part %d md5 mismatch (local: %s, etag: %s)", partNumber, partMD5, etag)
+			continue
+		}
+
 		// 上传成功
 		return oss.UploadPart{
 			PartNumber: int32(partNumber),
@@ -551,6 +597,50 @@ func (a *AliOssStorageClient) uploadPartWithRetry(
 	return oss.UploadPart{}, fmt.Errorf("failed after %d retries: %v", maxRetries+1, lastErr)
 }
 
+// md5SectionReader 读取分片的同时计算 MD5，Seek 回到开头时重新计算
+type md5SectionReader struct {
+	section *io.SectionReader
+	hash    hash.Hash
+	pos     int64 // 当前读取位置
+	hashed  int64 // 已计入 MD5 的字节数
+}
+
+func (r *md5SectionReader) Read(p []byte) (int, error) {
+	n, err := r.section.Read(p)
+	// 只计入从开头连续读取的内容
+	if r.pos == r.hashed {
+		r.hash.Write(p[:n])
+		r.hashed += int64(n)
+	}
+	r.pos += int64(n)
+	return n, err
+}
+
+func (r *md5SectionReader) Seek(offset int64, whence int) (int64, error) {
+	pos, err := r.section.Seek(offset, whence)
+	if err != nil {
+		return pos, err
+	}
+	r.pos = pos
+	if pos == 0 {
+		r.hash.Reset()
+		r.hashed = 0
+	}
+	return pos, nil
+}
+
+// sum 返回分片内容的 MD5（十六进制）；SDK 未从头完整读取时重新读取一遍
+func (r *md5SectionReader) sum() (string, error) {
+	if r.hashed != r.section.Size() {
+		h := md5.New()
+		if _, err := io.Copy(h, io.NewSectionReader(r.section, 0, r.section.Size())); err != nil {
+			return "", err
+		}
+		return hex.EncodeToString(h.Sum(nil)), nil
+	}
+	return hex.EncodeToString(r.hash.Sum(nil)), nil
+}
+
 // completeMultipartUpload 完成分片上传
 func (a *AliOssStorageClient) completeMultipartUpload(
 	ctx context.Context,
@@ -0,0 +1,158 @@
+package lib
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/hertz/cmd/hz/util/logs"
+	"github.com/siliconflow/bizyair-cli/meta"
+)
+
+// PendingCommit 文件已全部上传、但提交模型失败的记录
+// 保存上传得到的签名与封面地址，下次可直接提交而无需重新上传
+type PendingCommit struct {
+	BaseDomain string          `json:"base_domain"`
+	ModelName  string          `json:"model_name"`
+	ModelType  string          `json:"model_type"`
+	Versions   []*ModelVersion `json:"versions"`             // 提交的全部版本，包括沿用的远端已有版本
+	Uploaded   []string        `json:"uploaded,omitempty"`   // 本次上传的版本号，为空时全部版本均为本次上传
+	LastError  string          `json:"last_error,omitempty"` // 最近一次提交失败的原因
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+// UploadedVersions 返回记录中本次上传的版本，不含沿用的远端已有版本
+func (p *PendingCommit) UploadedVersions() []*ModelVersion {
+	if len(p.Uploaded) == 0 {
+		return p.Versions
+	}
+	uploaded := make(map[string]bool, len(p.Uploaded))
+	for _, name := range p.Uploaded {
+		uploaded[name] = true
+	}
+	versions := make([]*ModelVersion, 0, len(p.Uploaded))
+	for _, ver := range p.Versions {
+		if uploaded[ver.Version] {
+			versions = append(versions, ver)
+		}
+	}
+	return versions
+}
+
+// getPendingCommitFile 按 (域名, 类型, 模型名) 生成记录文件路径
+func getPendingCommitFile(baseDomain, modelType, modelName string) (string, error) {
+	dir, err := GetSfDir(meta.PendingCommitFolder)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(strings.Join([]string{baseDomain, modelType, modelName}, "\n")))
+	return filepath.Join(dir, hex.EncodeToString(sum[:])+".json"), nil
+}
+
+// SavePendingCommit 保存待提交记录，同一模型的旧记录会被覆盖
+func SavePendingCommit(pending *PendingCommit) error {
+	file, err := getPendingCommitFile(pending.BaseDomain, pending.ModelType, pending.ModelName)
+	if err != nil {
+		return err
+	}
+	if pending.CreatedAt.IsZero() {
+		pending.CreatedAt = time.Now()
+	}
+	data, err := json.MarshalIndent(pending, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal pending commit: %v", err)
+	}
+
+	// 先写同目录下的唯一临时文件再重命名，避免并发保存同一模型时互相覆盖或读到半截内容
+	tmp, err := os.CreateTemp(filepath.Dir(file), filepath.Base(file)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write pending commit: %v", err)
+	}
+	tmpFile := tmp.Name()
+	_, err = tmp.Write(data)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmpFile, file)
+	}
+	if err != nil {
+		_ = os.Remove(tmpFile)
+		return fmt.Errorf("failed to write pending commit: %v", err)
+	}
+	logs.Debugf("pending commit saved: %s\n", file)
+	return nil
+}
+
+// LoadPendingCommit 查询模型的待提交记录，不存在时返回 nil
+func LoadPendingCommit(baseDomain, modelType, modelName string) (*PendingCommit, error) {
+	file, err := getPendingCommitFile(baseDomain, modelType, modelName)
+	if err != nil {
+		return nil, err
+	}
+	return readPendingCommit(file)
+}
+
+// ListPendingCommits 列出所有待提交记录，按创建时间排序
+func ListPendingCommits() ([]*PendingCommit, error) {
+	dir, err := GetSfDir(meta.PendingCommitFolder)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pending commit directory: %v", err)
+	}
+
+	var list []*PendingCommit
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		pending, err := readPendingCommit(filepath.Join(dir, e.Name()))
+		if err != nil {
+			logs.Warnf("%v\n", err)
+			continue
+		}
+		if pending != nil {
+			list = append(list, pending)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].CreatedAt.Before(list[j].CreatedAt)
+	})
+	return list, nil
+}
+
+// DeletePendingCommit 删除模型的待提交记录
+func DeletePendingCommit(baseDomain, modelType, modelName string) error {
+	file, err := getPendingCommitFile(baseDomain, modelType, modelName)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete pending commit: %v", err)
+	}
+	return nil
+}
+
+func readPendingCommit(file string) (*PendingCommit, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read pending commit: %v", err)
+	}
+	var pending PendingCommit
+	if err := json.Unmarshal(data, &pending); err != nil {
+		return nil, fmt.Errorf("pending commit corrupted: %s: %v", file, err)
+	}
+	return &pending, nil
+}
@@ -0,0 +1,75 @@
+package lib
+
+import (
+	"context"
+	"io"
+
+	"golang.org/x/time/rate"
+)
+
+// rateLimitChunk 单次从令牌桶取令牌的最大字节数
+const rateLimitChunk = 64 * 1024
+
+// RateLimiter 上传限速器（令牌桶）
+// 同一个 RateLimiter 在所有并发上传（分片与简单上传）之间共享，限制的是总带宽
+type RateLimiter struct {
+	limiter *rate.Limiter
+	chunk   int
+}
+
+// NewRateLimiter 创建每秒 bytesPerSecond 字节的限速器，bytesPerSecond <= 0 时返回 nil（不限速）
+func NewRateLimiter(bytesPerSecond int64) *RateLimiter {
+	if bytesPerSecond <= 0 {
+		return nil
+	}
+	chunk := rateLimitChunk
+	if bytesPerSecond < int64(chunk) {
+		chunk = int(bytesPerSecond)
+	}
+	return &RateLimiter{
+		limiter: rate.NewLimiter(rate.Limit(bytesPerSecond), chunk),
+		chunk:   chunk,
+	}
+}
+
+// WrapReader 返回按限速读取的 reader，限速器为 nil 时原样返回
+// 底层 reader 支持 Seek 时返回值同样支持 Seek，便于 OSS SDK 重试时回绕
+func (l *RateLimiter) WrapReader(ctx context.Context, r io.Reader) io.Reader {
+	if l == nil {
+		return r
+	}
+	lr := &rateLimitedReader{ctx: ctx, reader: r, limiter: l}
+	if seeker, ok := r.(io.Seeker); ok {
+		return &rateLimitedReadSeeker{rateLimitedReader: lr, seeker: seeker}
+	}
+	return lr
+}
+
+// rateLimitedReader 每次读取后按实际读取的字节数等待令牌
+type rateLimitedReader struct {
+	ctx     context.Context
+	reader  io.Reader
+	limiter *RateLimiter
+}
+
+func (r *rateLimitedReader) Read(p []byte) (int, error) {
+	if len(p) > r.limiter.chunk {
+		p = p[:r.limiter.chunk]
+	}
+	n, err := r.reader.Read(p)
+	if n > 0 {
+		if werr := r.limiter.limiter.WaitN(r.ctx, n); werr != nil {
+			return n, werr
+		}
+	}
+	return n, err
+}
+
+type rateLimitedReadSeeker struct {
+	*rateLimitedReader
+	seeker io.Seeker
+}
+
+func (r *rateLimitedReadSeeker) Seek(offset int64, whence int) (int64, error) {
+	return r.seeker.Seek(offset, whence)
+}
@@ -0,0 +1,154 @@
+package lib
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/siliconflow/bizyair-cli/meta"
+)
+
+// ErrSchedulerClosed 调度器已关闭
+var ErrSchedulerClosed = errors.New("upload scheduler closed")
+
+// UploadScheduler 全局上传调度器
+// 所有文件、版本、模型的分片（及简单上传）共用一个固定大小的 worker 池，
+// 按分组（通常是文件）轮询取任务，避免大文件独占全部并发
+// 同时限制哈希计算的并发数，避免多个大文件同时读盘
+type UploadScheduler struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	groups  []*taskGroup          // 有待执行任务的分组，按轮询顺序排列
+	byName  map[string]*taskGroup // 分组名 -> 分组
+	next    int                   // 下一个取任务的分组下标
+	closed  bool
+	workers int
+	wg      sync.WaitGroup
+
+	hashSem chan struct{}
+}
+
+type taskGroup struct {
+	name  string
+	tasks []*scheduledTask
+}
+
+type scheduledTask struct {
+	ctx  context.Context
+	fn   func() error
+	done chan error
+}
+
+// NewUploadScheduler 创建并启动 workers 个 worker 的调度器，workers <= 0 时使用默认并发数
+func NewUploadScheduler(workers int) *UploadScheduler {
+	if workers <= 0 {
+		workers = meta.UploadParallel
+	}
+	s := &UploadScheduler{
+		byName:  make(map[string]*taskGroup),
+		workers: workers,
+		hashSem: make(chan struct{}, meta.HashParallel),
+	}
+	s.cond = sync.NewCond(&s.mu)
+	s.wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go s.worker()
+	}
+	return s
+}
+
+// Workers 返回 worker 数量（即全局并发上传请求数）
+func (s *UploadScheduler) Workers() int {
+	return s.workers
+}
+
+// Do 将任务加入 group 分组并等待其执行完成，返回任务的错误
+// ctx 取消时尚未开始的任务会被跳过；已开始的任务执行完毕后才返回 ctx.Err()，
+// 保证返回后 fn 不再访问调用方的数据
+func (s *UploadScheduler) Do(ctx context.Context, group string, fn func() error) error {
+	task := &scheduledTask{ctx: ctx, fn: fn, done: make(chan error, 1)}
+
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return ErrSchedulerClosed
+	}
+	g := s.byName[group]
+	if g == nil {
+		g = &taskGroup{name: group}
+		s.byName[group] = g
+		s.groups = append(s.groups, g)
+	}
+	g.tasks = append(g.tasks, task)
+	s.cond.Signal()
+	s.mu.Unlock()
+
+	select {
+	case err := <-task.done:
+		return err
+	case <-ctx.Done():
+		<-task.done
+		return ctx.Err()
+	}
+}
+
+// Hash 在哈希并发限制内执行 fn
+func (s *UploadScheduler) Hash(ctx context.Context, fn func() error) error {
+	select {
+	case s.hashSem <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	defer func() { <-s.hashSem }()
+	return fn()
+}
+
+// Close 停止接收新任务，等待已排队的任务执行完毕后退出所有 worker
+func (s *UploadScheduler) Close() {
+	s.mu.Lock()
+	s.closed = true
+	s.cond.Broadcast()
+	s.mu.Unlock()
+	s.wg.Wait()
+}
+
+func (s *UploadScheduler) worker() {
+	defer s.wg.Done()
+	for {
+		s.mu.Lock()
+		for len(s.groups) == 0 && !s.closed {
+			s.cond.Wait()
+		}
+		if len(s.groups) == 0 {
+			s.mu.Unlock()
+			return
+		}
+		task := s.pop()
+		s.mu.Unlock()
+
+		if err := task.ctx.Err(); err != nil {
+			task.done <- err
+			continue
+		}
+		task.done <- task.fn()
+	}
+}
+
+// pop 轮询取出下一个任务，调用方需持有锁
+func (s *UploadScheduler) pop() *scheduledTask {
+	if s.next >= len(s.groups) {
+		s.next = 0
+	}
+	g := s.groups[s.next]
+	task := g.tasks[0]
+	g.tasks = g.tasks[1:]
+
+	if len(g.tasks) == 0 {
+		// 分组已空，移出轮询队列；下标不变即指向下一个分组
+		s.groups = append(s.groups[:s.next], s.groups[s.next+1:]...)
+		delete(s.byName, g.name)
+	} else {
+		s.next++
+	}
+	return task
+}
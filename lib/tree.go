@@ -2,6 +2,8 @@ package lib
 
 import (
 	"fmt"
+	"io"
+	"os"
 	"sort"
 	"strings"
 )
@@ -34,6 +36,11 @@ func (n *Node) AddPath(path string) {
 
 // PrintTree prints the tree in a tree-like format
 func (n *Node) PrintTree(indent string) {
+	n.FprintTree(os.Stdout, indent)
+}
+
+// FprintTree writes the tree in a tree-like format to w
+func (n *Node) FprintTree(w io.Writer, indent string) {
 	keys := make([]string, 0, len(n.Children))
 	for key := range n.Children {
 		keys = append(keys, key)
@@ -42,12 +49,12 @@ func (n *Node) PrintTree(indent string) {
 
 	for i, key := range keys {
 		if i == len(keys)-1 {
-			fmt.Printf("%s└── %s\n", indent, key)
+			fmt.Fprintf(w, "%s└── %s\n", indent, key)
 			node := n.Children[key]
-			node.PrintTree(indent + "    ")
+			node.FprintTree(w, indent+"    ")
 		} else {
-			fmt.Printf("%s├── %s\n", indent, key)
-			n.Children[key].PrintTree(indent + "│   ")
+			fmt.Fprintf(w, "%s├── %s\n", indent, key)
+			n.Children[key].FprintTree(w, indent+"│   ")
 		}
 	}
 }
@@ -60,13 +60,14 @@ type ModelFile struct {
 }
 
 type ModelVersion struct {
-	Version      string   `json:"version,omitempty" form:"version" query:"version"`
-	BaseModel    string   `json:"base_model,omitempty" form:"base_model" query:"base_model"`
-	Introduction string   `json:"intro,omitempty" form:"intro" query:"intro"`
-	Public       bool     `json:"public,omitempty" form:"public" query:"public"`
-	Sign         string   `json:"sign,omitempty" form:"sign" query:"sign"`
-	Path         string   `json:"path,omitempty" form:"path" query:"path"`
-	CoverUrls    []string `json:"cover_urls,omitempty" form:"cover_urls" query:"cover_urls"`
+	Version      string       `json:"version,omitempty" form:"version" query:"version"`
+	BaseModel    string       `json:"base_model,omitempty" form:"base_model" query:"base_model"`
+	Introduction string       `json:"intro,omitempty" form:"intro" query:"intro"`
+	Public       bool         `json:"public,omitempty" form:"public" query:"public"`
+	Sign         string       `json:"sign,omitempty" form:"sign" query:"sign"`
+	Path         string       `json:"path,omitempty" form:"path" query:"path"`
+	CoverUrls    []string     `json:"cover_urls,omitempty" form:"cover_urls" query:"cover_urls"`
+	Files        []*ModelFile `json:"files,omitempty" form:"files" query:"files"` // 目录上传时的文件列表（Path 为相对路径）
 }
 
 type OssSignReq struct {
@@ -217,6 +218,7 @@ type BizyModelDetailVersion struct {
 	Sign        string       `json:"sign,omitempty"`
 	Path        string       `json:"path,omitempty"`
 	Available   bool         `json:"available,omitempty"`
+	Public      bool         `json:"public,omitempty"` // 详情接口不返回，由列表接口按版本 id 填入
 	FileName    string       `json:"file_name,omitempty"`
 	BizyModelId int64        `json:"bizy_model_id,omitempty"`
 	UserId      string       `json:"user_id,omitempty"`
@@ -154,13 +154,15 @@ func PerformUpgrade(opts UpgradeOptions) *UpgradeResult {
 	// 5. 下载新版本到临时文件
 	updateStatus(fmt.Sprintf("正在下载新版本 %s...", result.LatestVersion))
 	tempDir := os.TempDir()
-	tempFile := filepath.Join(tempDir, binary.Filename+".tmp")
+	// 文件名带上版本号，中断后再次升级到同一版本时可续传
+	tempFile := filepath.Join(tempDir, fmt.Sprintf("%s.%s.tmp", binary.Filename, result.LatestVersion))
 
 	err = DownloadFile(DownloadFileOptions{
 		URL:          binary.URL,
 		DestPath:     tempFile,
 		ProgressFunc: opts.ProgressFunc,
 		Context:      ctx,
+		Parallel:     meta.DownloadParallel,
 	})
 	if err != nil {
 		result.Success = false
@@ -8,6 +8,7 @@ import (
 
 	"github.com/cloudwego/hertz/cmd/hz/util/logs"
 	"github.com/siliconflow/bizyair-cli/lib/filehash"
+	"github.com/siliconflow/bizyair-cli/meta"
 )
 
 // UploadOptions 上传选项
@@ -17,7 +18,12 @@ type UploadOptions struct {
 	ModelType    string           // 模型类型
 	Context      context.Context  // 上下文（用于取消）
 	ProgressFunc ProgressCallback // 进度回调函数
+	HashFunc     ProgressCallback // 哈希计算进度回调函数
 	FileIndex    string           // 文件索引（如 "1/3"）
+	NoHashCache  bool             // 不使用本地哈希缓存
+	Multipart    MultipartConfig  // 分片上传配置（零值使用默认配置）
+	RateLimiter  *RateLimiter     // 上传限速器（nil 不限速），多个上传共享同一个实例
+	Scheduler    *UploadScheduler // 全局上传调度器（nil 时为本次上传创建临时调度器）
 }
 
 // UnifiedUpload 统一上传逻辑（支持断点续传和分片上传）
@@ -27,6 +33,11 @@ func UnifiedUpload(opts UploadOptions) (string, error) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
+	scheduler := opts.Scheduler
+	if scheduler == nil {
+		scheduler = NewUploadScheduler(meta.UploadParallel)
+		defer scheduler.Close()
+	}
 
 	// 1. 获取文件信息
 	st, err := os.Stat(opts.File.Path)
@@ -42,10 +53,33 @@ func UnifiedUpload(opts UploadOptions) (string, error) {
 		opts.File.Size = st.Size()
 	}
 
-	// 2. 计算文件哈希
-	sha256sum, md5Hash, err := filehash.CalculateHash(opts.File.Path)
-	if err != nil {
-		return "", WithStep("计算哈希", err)
+	// 2. 计算文件哈希（文件未变化时直接使用本地缓存）
+	sha256sum, md5Hash, cached := "", "", false
+	var before *HashCacheEntry
+	if !opts.NoHashCache {
+		sha256sum, md5Hash, cached = LoadHashCache(opts.File.Path)
+	}
+	if !cached {
+		if !opts.NoHashCache {
+			// 哈希前记录文件标识，保存缓存时据此确认计算期间文件未被修改
+			before, _ = newHashCacheEntry(opts.File.Path)
+		}
+		err = scheduler.Hash(ctx, func() error {
+			var herr error
+			sha256sum, md5Hash, herr = filehash.CalculateHashCtx(ctx, opts.File.Path, opts.HashFunc)
+			return herr
+		})
+		if err != nil {
+			if errors.Is(err, context.Canceled) {
+				return "", err
+			}
+			return "", WithStep("计算哈希", err)
+		}
+		if before != nil {
+			if err := SaveHashCache(before, sha256sum, md5Hash); err != nil {
+				logs.Warnf("[%s] 保存哈希缓存失败: %v\n", opts.FileIndex, err)
+			}
+		}
 	}
 	opts.File.Signature = sha256sum
 
@@ -90,7 +124,7 @@ func UnifiedUpload(opts UploadOptions) (string, error) {
 
 	// 5. 如果没有有效的 OSS 客户端，获取新的签名
 	if ossClient == nil {
-		ossCert, err := opts.Client.OssSign(sha256sum, opts.ModelType)
+		ossCert, err := opts.Client.OssSign(ctx, sha256sum, opts.ModelType)
 		if err != nil {
 			return "", WithStep("获取上传签名", err)
 		}
@@ -127,6 +161,9 @@ func UnifiedUpload(opts UploadOptions) (string, error) {
 	}
 
 	// 6. 使用分片上传（自动支持断点续传）
+	ossClient.SetMultipartConfig(opts.Multipart)
+	ossClient.SetRateLimiter(opts.RateLimiter)
+	ossClient.SetScheduler(scheduler)
 	_, err = ossClient.UploadFileMultipart(ctx, opts.File, objectKey, opts.FileIndex, opts.ProgressFunc)
 	if err != nil {
 		// 检查是否是用户取消
@@ -141,7 +178,7 @@ func UnifiedUpload(opts UploadOptions) (string, error) {
 	if opts.File.RemoteKey != "" {
 		commitKey = opts.File.RemoteKey
 	}
-	_, err = opts.Client.CommitFileV2(sha256sum, commitKey, md5Hash, opts.ModelType)
+	_, err = opts.Client.CommitFileV2(ctx, sha256sum, commitKey, md5Hash, opts.ModelType)
 	if err != nil {
 		return "", WithStep("提交文件", err)
 	}
@@ -1,6 +1,7 @@
 package lib
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -28,7 +29,7 @@ func IsHTTPURL(s string) bool {
 
 // DownloadToTemp 下载 URL 到临时文件
 // 返回临时文件路径、清理函数、错误
-func DownloadToTemp(url string) (string, func(), error) {
+func DownloadToTemp(ctx context.Context, url string) (string, func(), error) {
 	// 创建临时文件
 	tmpFile, err := os.CreateTemp("", "cover-*"+filepath.Ext(url))
 	if err != nil {
@@ -41,11 +42,17 @@ func DownloadToTemp(url string) (string, func(), error) {
 	}
 
 	// 下载文件
-	resp, err := http.Get(url)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		tmpFile.Close()
 		cleanup()
-		return "", nil, fmt.Errorf("下载失败: %v", err)
+		return "", nil, fmt.Errorf("下载失败: %w", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		tmpFile.Close()
+		cleanup()
+		return "", nil, fmt.Errorf("下载失败: %w", err)
 	}
 	defer resp.Body.Close()
 
@@ -60,7 +67,7 @@ func DownloadToTemp(url string) (string, func(), error) {
 	tmpFile.Close()
 	if err != nil {
 		cleanup()
-		return "", nil, fmt.Errorf("写入文件失败: %v", err)
+		return "", nil, fmt.Errorf("写入文件失败: %w", err)
 	}
 
 	return tmpPath, cleanup, nil
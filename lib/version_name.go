@@ -0,0 +1,57 @@
+package lib
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+var (
+	versionNumberRe      = regexp.MustCompile(`^v?(\d+)\..*`)
+	versionMajorNumberRe = regexp.MustCompile(`^v?(\d+)$`)
+)
+
+// AutoIncrementVersionNames 为空的版本名生成递增的版本号（vN.0）
+// 从 names 与 existing（如远端已有的版本）中的最大版本号开始递增，返回新的切片
+func AutoIncrementVersionNames(names []string, existing []string) []string {
+	maxVersionNum := 0
+	for _, list := range [][]string{existing, names} {
+		for _, name := range list {
+			if num := ExtractVersionNumber(name); num > maxVersionNum {
+				maxVersionNum = num
+			}
+		}
+	}
+
+	result := make([]string, len(names))
+	currentNum := maxVersionNum
+	for i, name := range names {
+		if name == "" {
+			currentNum++
+			name = fmt.Sprintf("v%d.0", currentNum)
+		}
+		result[i] = name
+	}
+	return result
+}
+
+// ExtractVersionNumber 从版本号字符串中提取主版本号数字
+// 例如: "v1.0" -> 1, "v2.5" -> 2, "v10.0" -> 10
+// 如果无法提取，返回 0
+func ExtractVersionNumber(version string) int {
+	// 匹配 vX.Y 格式的版本号
+	if matches := versionNumberRe.FindStringSubmatch(version); len(matches) >= 2 {
+		if num, err := strconv.Atoi(matches[1]); err == nil {
+			return num
+		}
+	}
+
+	// 匹配 vX 格式的版本号
+	if matches := versionMajorNumberRe.FindStringSubmatch(version); len(matches) >= 2 {
+		if num, err := strconv.Atoi(matches[1]); err == nil {
+			return num
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,71 @@
+package lib
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/samber/lo"
+	"github.com/siliconflow/bizyair-cli/meta"
+)
+
+// CollectUploadFiles 遍历目录，收集需要上传的文件
+// 跳过 meta.IgnoreUploadDirs 中的目录，RelPath 为相对 root 的路径（统一使用 / 分隔）
+func CollectUploadFiles(root string) ([]*FileToUpload, error) {
+	var files []*FileToUpload
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != root && lo.Contains(meta.IgnoreUploadDirs, d.Name()) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		// 仅上传普通文件（忽略符号链接、设备文件等）
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, &FileToUpload{
+			Path:    filepath.ToSlash(path),
+			RelPath: filepath.ToSlash(relPath),
+			Size:    info.Size(),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("遍历目录失败: %w", err)
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].RelPath < files[j].RelPath
+	})
+	return files, nil
+}
+
+// BuildFileTree 根据文件的相对路径构建目录树
+func BuildFileTree(root string, files []*FileToUpload) *Node {
+	tree := NewNode(filepath.Base(root))
+	for _, file := range files {
+		tree.AddPath(file.RelPath)
+	}
+	return tree
+}
+
+// IsDir 判断路径是否为目录
+func IsDir(path string) bool {
+	st, err := os.Stat(path)
+	return err == nil && st.IsDir()
+}
@@ -1,9 +1,12 @@
 package main
 
 import (
+	"context"
+	"os"
+	"os/signal"
+
 	"github.com/cloudwego/hertz/cmd/hz/util/logs"
 	"github.com/siliconflow/bizyair-cli/cmd"
-	"os"
 )
 
 func main() {
@@ -15,8 +18,21 @@ func Run() {
 		logs.Flush()
 	}()
 
+	// 首次 Ctrl+C 取消当前操作（保留已上传分片的 checkpoint），再次 Ctrl+C 直接退出
+	// 不使用 signal.NotifyContext：它以信号作为取消原因，HTTP 请求返回的错误不再是 context.Canceled，
+	// 各处无法按 errors.Is(err, context.Canceled) 识别为用户取消
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt)
+	go func() {
+		<-sigCh
+		cancel()
+		signal.Stop(sigCh)
+	}()
+
 	cli := cmd.Init()
-	err := cli.Run(os.Args)
+	err := cli.RunContext(ctx, os.Args)
 	if err != nil {
 		logs.Errorf("%v\n", err)
 	}
@@ -2,6 +2,7 @@ package meta
 
 import (
 	"strings"
+	"time"
 
 	"github.com/samber/lo"
 )
@@ -15,8 +16,15 @@ const (
 	CmdLsFiles = "ls-files"
 	CmdDetail  = "detail"
 	CmdRm      = "rm"
+	CmdEdit    = "edit"
+	CmdPull    = "pull"
+	CmdExport  = "export"
 	CmdCommit  = "commit"
+	CmdPlan    = "plan"
+	CmdApply   = "apply"
 	CmdUpgrade = "upgrade"
+	CmdCache   = "cache"
+	CmdClear   = "clear"
 )
 
 const (
@@ -25,23 +33,55 @@ const (
 	StorageDomain = "https://storage.bizyair.cn"
 )
 
+const (
+	// 输出格式（--output）
+	OutputText = ""
+	OutputJSON = "json"
+	OutputYAML = "yaml"
+)
+
+const (
+	// API 请求超时与重试策略
+	HTTPTimeout     = 60 * time.Second
+	HTTPMaxRetries  = 3
+	HTTPBaseBackoff = 500 * time.Millisecond
+	HTTPMaxBackoff  = 30 * time.Second
+)
+
 const (
 	LoadError   = 1
 	ServerError = 2
 	HttpError   = 3
+	CancelError = 130 // 用户按 Ctrl+C 取消，与 shell 中 SIGINT 的退出码一致
 )
 
 const (
 	// 分片上传配置
-	MultipartPartSize  = 5 * 1024 * 1024   // 每个分片5MB（与前端一致）
-	MultipartParallel  = 3                 // 并发上传3个分片（与前端一致）
-	MultipartThreshold = 100 * 1024 * 1024 // 超过100MB使用分片上传
-	CheckpointFolder   = "uploads"         // checkpoint文件夹名称
+	MultipartPartSize   = 5 * 1024 * 1024        // 每个分片5MB（与前端一致）
+	UploadParallel      = 6                      // 全局并发上传请求数（所有文件的分片共享）
+	HashParallel        = 2                      // 同时计算哈希的文件数
+	MultipartThreshold  = 100 * 1024 * 1024      // 超过100MB使用分片上传
+	MultipartMaxParts   = 10000                  // OSS 单个文件最多10000个分片
+	MultipartMinSize    = 100 * 1024             // OSS 分片最小100KB（最后一个分片除外）
+	MultipartMaxSize    = 5 * 1024 * 1024 * 1024 // OSS 分片最大5GB
+	CheckpointFolder    = "uploads"              // checkpoint文件夹名称
+	HashCacheFolder     = "hashes"               // 文件哈希缓存文件夹名称
+	PendingCommitFolder = "commits"              // 待提交模型记录文件夹名称
+	BatchFolder         = "batches"              // YAML 批量上传进度记录文件夹名称
+	LockFileSuffix      = ".lock.json"           // YAML 批量上传的锁文件后缀，如 models.yaml → models.lock.json
+	LockFileVersion     = 1                      // 锁文件格式版本
 
 	// 升级相关配置
 	ManifestURL         = StorageDomain + "/cli/releases/manifest.json"
 	UpgradeBackupSuffix = ".backup"
 	UpgradeMaxRetries   = 3
+
+	// 下载相关配置
+	DownloadPartSuffix      = ".part"          // 未下载完成的文件后缀，用于断点续传
+	DownloadStateSuffix     = ".part.json"     // 下载进度记录后缀，保存服务端文件的 ETag/Last-Modified 及并行下载的分块进度
+	DownloadParallel        = 4                // 大文件并行下载的分块请求数
+	DownloadChunkSize       = 16 * 1024 * 1024 // 并行下载每个分块16MB
+	DownloadParallelMinSize = 64 * 1024 * 1024 // 超过64MB才使用并行下载
 )
 
 type UploadFileType string
@@ -94,6 +134,12 @@ const (
 	EnvUserProfile          = "USERPROFILE"
 	EnvHome                 = "HOME"
 	EnvAPIKey               = "SF_API_KEY"
+	EnvPartSize             = "BIZYAIR_PART_SIZE"
+	EnvParallel             = "BIZYAIR_PARALLEL"
+	EnvMultipartThreshold   = "BIZYAIR_MULTIPART_THRESHOLD"
+	EnvLimitRate            = "BIZYAIR_LIMIT_RATE"
+	EnvHTTPTimeout          = "BIZYAIR_HTTP_TIMEOUT"
+	EnvHTTPRetries          = "BIZYAIR_HTTP_RETRIES"
 	OSSObjectKey            = "https://%s.%s.aliyuncs.com/%s"
 	OKCode                  = 20000
 )